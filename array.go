@@ -0,0 +1,117 @@
+package sqrl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// inListChunkSize is the maximum number of values in a single IN list of an
+// expanded array, Oracle doesn't accept more.
+const inListChunkSize = 1000
+
+// paramLimit returns the maximum number of parameters in a statement of
+// dialect d, or 0 if it is not limited.
+func paramLimit(d Dialect) int {
+	switch d {
+	case SQLServer:
+		return 2100
+	case SQLite:
+		return 32766
+	case MySQL:
+		return 65535
+	}
+	return 0
+}
+
+// arrayValue is a list value bound as a single array parameter.
+type arrayValue struct {
+	values interface{}
+}
+
+// Array marks a list value of Eq or Neq to be bound as a single array
+// parameter, so the statement doesn't depend on the list length:
+// "id = ANY(?)" and "id <> ALL(?)" instead of "id IN (?,?,?)".
+//
+// The values are either a slice, which is bound as is (e.g. for pgx), or an
+// array driver.Valuer like pq.Array(ids). Dialects other than Postgres have no
+// arrays, so a slice is expanded into IN lists of at most 1000 values joined
+// with OR. That only works around the IN list limit of Oracle: every value is
+// still a parameter, and a list over the parameter limit of the dialect, e.g.
+// 2100 for SQL Server, is an error.
+//
+// See StatementBuilderType.BindArrays to bind all slices as arrays.
+// Ex:
+//
+//	.Where(Eq{"id": Array(ids)}) == "id = ANY(?)"
+func Array(values interface{}) arrayValue {
+	return arrayValue{values: values}
+}
+
+// isList reports whether val is a list of values. []byte is a single value.
+func isList(val interface{}) bool {
+	if _, ok := val.([]byte); ok {
+		return false
+	}
+
+	kind := reflect.ValueOf(val).Kind()
+	return kind == reflect.Array || kind == reflect.Slice
+}
+
+// appendArrayToSQL writes comparison of key with array val.
+func appendArrayToSQL(b *bytes.Buffer, key string, val interface{}, d Dialect, useNotOpr bool, args []interface{}) ([]interface{}, error) {
+	if d == Postgres {
+		if useNotOpr {
+			b.WriteString(key + " <> ALL(?)")
+		} else {
+			b.WriteString(key + " = ANY(?)")
+		}
+		return append(args, val), nil
+	}
+
+	if !isList(val) {
+		return nil, fmt.Errorf("array value must be a slice to be expanded for %s dialect", d)
+	}
+
+	valVal := reflect.ValueOf(val)
+	n := valVal.Len()
+	if n == 0 {
+		return nil, errors.New("equality condition must contain at least one paramater")
+	}
+
+	if limit := paramLimit(d); limit > 0 && n > limit {
+		return nil, fmt.Errorf("%d values exceed the limit of %d parameters of %s dialect", n, limit, d)
+	}
+
+	inOpr, sep := " IN (", " OR "
+	if useNotOpr {
+		inOpr, sep = " NOT IN (", " AND "
+	}
+
+	if n > inListChunkSize {
+		b.WriteByte('(')
+	}
+
+	for start := 0; start < n; start += inListChunkSize {
+		end := start + inListChunkSize
+		if end > n {
+			end = n
+		}
+
+		if start > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(key + inOpr + Placeholders(end-start) + ")")
+
+		for i := start; i < end; i++ {
+			args = append(args, valVal.Index(i).Interface())
+		}
+	}
+
+	if n > inListChunkSize {
+		b.WriteByte(')')
+	}
+
+	return args, nil
+}
@@ -0,0 +1,99 @@
+package sqrl
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArray(t *testing.T) {
+	ids := []int{1, 2, 3}
+	q := Select("*").From("t").Where(Eq{"id": Array(ids)}).Where(Neq{"parent_id": Array(ids)}).Where(Eq{"x": []int{4, 5}})
+
+	sql, args, err := q.Dialect(Postgres).PlaceholderFormat(Dollar).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE id = ANY($1) AND parent_id <> ALL($2) AND x IN ($3,$4)", sql)
+	assert.Equal(t, []interface{}{ids, ids, 4, 5}, args)
+
+	sql, args, err = q.Dialect(MySQL).PlaceholderFormat(Question).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE id IN (?,?,?) AND parent_id NOT IN (?,?,?) AND x IN (?,?)", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, 1, 2, 3, 4, 5}, args)
+
+	_, err = Eq{"id": Array([]int{})}.WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+
+	_, err = Eq{"id": Array("{1,2}")}.WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestArrayChunks(t *testing.T) {
+	ids := make([]int, 2500)
+	for i := range ids {
+		ids[i] = i
+	}
+
+	b := &bytes.Buffer{}
+	args, err := Eq{"id": Array(ids)}.WriteSQL(b)
+
+	assert.NoError(t, err)
+	assert.Len(t, args, 2500)
+	assert.Equal(t, "(id IN ("+Placeholders(1000)+") OR id IN ("+Placeholders(1000)+") OR id IN ("+Placeholders(500)+"))", b.String())
+
+	b.Reset()
+	_, err = Neq{"id": Array(ids)}.WriteSQL(b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(b.String(), ") AND id NOT IN ("))
+
+	_, _, err = Select("a").From("t").Where(Eq{"id": ids}).BindArrays(true).Dialect(SQLServer).ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = Select("a").From("t").Where(Eq{"id": ids}).BindArrays(true).Dialect(Oracle).ToSQL()
+	assert.NoError(t, err)
+}
+
+func TestStatementBuilderBindArrays(t *testing.T) {
+	sb := StatementBuilder.Dialect(Postgres).BindArrays(true)
+
+	sql, args, err := sb.Select("*").
+		From("t").
+		Where(Or{Eq{"id": []int64{1, 2}}, Eq{"code": []byte("x")}}).
+		Where(Eq{"y": 3}).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE (id = ANY(?) OR code IN (?)) AND y = ?", sql)
+	assert.Equal(t, []interface{}{[]int64{1, 2}, byte('x'), 3}, args)
+
+	sql, _, err = sb.Dialect(SQLite).Delete("t").Where(Neq{"id": []int{1, 2}}).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM t WHERE id NOT IN (?,?)", sql)
+}
+
+func TestBuilderBindArrays(t *testing.T) {
+	sql, args, err := Select("*").
+		From("t").
+		Where(Eq{"id": []int{1, 2}}).
+		Dialect(Postgres).
+		BindArrays(true).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE id = ANY($1)", sql)
+	assert.Equal(t, []interface{}{[]int{1, 2}}, args)
+
+	sql, _, err = Update("t").Set("a", 1).Where(Neq{"id": []int{1, 2}}).Dialect(Postgres).BindArrays(true).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE t SET a = ? WHERE id <> ALL(?)", sql)
+
+	sql, _, err = Delete("t").Where(Eq{"id": []int{1, 2}}).Dialect(Postgres).BindArrays(true).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM t WHERE id = ANY(?)", sql)
+
+	sql, _, err = StatementBuilder.Dialect(Postgres).BindArrays(true).Select("*").From("t").Where(Eq{"id": []int{1, 2}}).BindArrays(false).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE id IN (?,?)", sql)
+}
@@ -9,18 +9,19 @@ import (
 // without constant checks for errors that may come from Sqlizer
 type sqlizerBuffer struct {
 	b    *bytes.Buffer
+	d    dialectOptions
 	args []interface{}
 	err  error
 }
 
 // WriteSQL converts Sqlizer to SQL strings and writes it to buffer
-func (b *sqlizerBuffer) WriteSQL(item sqlWriter) {
+func (b *sqlizerBuffer) WriteSQL(item Sqlizer) {
 	if b.err != nil {
 		return
 	}
 
 	var args []interface{}
-	args, b.err = item.toSQL(b.b)
+	args, b.err = writeSQL(b.b, item, b.d)
 
 	if b.err != nil {
 		return
@@ -35,8 +36,8 @@ func (b *sqlizerBuffer) WriteSQL(item sqlWriter) {
 
 // whenPart is a helper structure to describe SQLs "WHEN ... THEN ..." expression
 type whenPart struct {
-	when sqlWriter
-	then sqlWriter
+	when Sqlizer
+	then Sqlizer
 }
 
 func newWhenPart(when interface{}, then interface{}) whenPart {
@@ -45,18 +46,22 @@ func newWhenPart(when interface{}, then interface{}) whenPart {
 
 // CaseBuilder builds SQL CASE construct which could be used as parts of queries.
 type CaseBuilder struct {
-	whatPart  sqlWriter
+	whatPart  Sqlizer
 	whenParts []whenPart
-	elsePart  sqlWriter
+	elsePart  Sqlizer
 }
 
-// toSql implements sqlWriter
-func (b *CaseBuilder) toSQL(s *bytes.Buffer) ([]interface{}, error) {
+// WriteSQL implements Sqlizer
+func (b *CaseBuilder) WriteSQL(s *bytes.Buffer) ([]interface{}, error) {
+	return b.writeDialectSQL(s, dialectOptions{})
+}
+
+func (b *CaseBuilder) writeDialectSQL(s *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
 	if len(b.whenParts) == 0 {
 		return nil, errors.New("case expression must contain at lease one WHEN clause")
 	}
 
-	sql := sqlizerBuffer{b: s}
+	sql := sqlizerBuffer{b: s, d: d}
 
 	s.WriteString("CASE ")
 	if b.whatPart != nil {
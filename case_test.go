@@ -14,7 +14,7 @@ func BenchmarkCaseToSQL(b *testing.B) {
 		Else(Expr("?", "big number"))
 
 	for n := 0; n < b.N; n++ {
-		caseStmt.toSQL(&bytes.Buffer{})
+		caseStmt.WriteSQL(&bytes.Buffer{})
 	}
 }
 
@@ -25,7 +25,7 @@ func TestCaseWithVal(t *testing.T) {
 		Else(Expr("?", "big number"))
 
 	b := &bytes.Buffer{}
-	args, err := caseStmt.toSQL(b)
+	args, err := caseStmt.WriteSQL(b)
 
 	assert.NoError(t, err)
 
@@ -45,7 +45,7 @@ func TestCaseWithComplexVal(t *testing.T) {
 		When("true", "'T'")
 
 	b := &bytes.Buffer{}
-	args, err := caseStmt.toSQL(b)
+	args, err := caseStmt.WriteSQL(b)
 
 	assert.NoError(t, err)
 
@@ -64,7 +64,7 @@ func TestCaseWithNoVal(t *testing.T) {
 		When(Expr("x > ?", 1), Expr("CONCAT('x is greater than ', ?)", 2))
 
 	b := &bytes.Buffer{}
-	args, err := caseStmt.toSQL(b)
+	args, err := caseStmt.WriteSQL(b)
 
 	assert.NoError(t, err)
 
@@ -85,7 +85,7 @@ func TestCaseWithExpr(t *testing.T) {
 		Else("42")
 
 	b := &bytes.Buffer{}
-	args, err := caseStmt.toSQL(b)
+	args, err := caseStmt.WriteSQL(b)
 
 	assert.NoError(t, err)
 
@@ -104,7 +104,7 @@ func TestCaseWithNoWhenClause(t *testing.T) {
 	caseStmt := Case("something").
 		Else("42")
 
-	_, err := caseStmt.toSQL(&bytes.Buffer{})
+	_, err := caseStmt.WriteSQL(&bytes.Buffer{})
 
 	assert.Error(t, err)
 
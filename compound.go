@@ -0,0 +1,200 @@
+package sqrl
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+type compoundPart struct {
+	op      string
+	selectB *SelectBuilder
+}
+
+// CompoundBuilder builds compound SQL SELECT statements combining results of
+// several SelectBuilders with UNION, INTERSECT and EXCEPT.
+type CompoundBuilder struct {
+	StatementBuilderType
+
+	parts    []compoundPart
+	orderBys []string
+
+	pagination
+}
+
+// NewCompoundBuilder creates new instance of CompoundBuilder
+func NewCompoundBuilder(b StatementBuilderType) *CompoundBuilder {
+	return &CompoundBuilder{StatementBuilderType: b}
+}
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Query.
+func (b *CompoundBuilder) RunWith(runner BaseRunner) *CompoundBuilder {
+	b.runWith = runner
+	return b
+}
+
+// Query builds and Querys the query with the Runner set by RunWith.
+func (b *CompoundBuilder) Query() (*sql.Rows, error) {
+	return b.QueryContext(context.Background())
+}
+
+// QueryContext builds and Querys the query with the Runner set by RunWith in given context.
+func (b *CompoundBuilder) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	return QueryWithContext(ctx, b.runWith, b)
+}
+
+// QueryRow builds and QueryRows the query with the Runner set by RunWith.
+func (b *CompoundBuilder) QueryRow() RowScanner {
+	return b.QueryRowContext(context.Background())
+}
+
+// QueryRowContext builds and QueryRows the query with the Runner set by RunWith in given context.
+func (b *CompoundBuilder) QueryRowContext(ctx context.Context) RowScanner {
+	if b.runWith == nil {
+		return &Row{err: ErrRunnerNotSet}
+	}
+	queryRower, ok := b.runWith.(QueryRowerContext)
+	if !ok {
+		return &Row{err: ErrRunnerNotQueryRunnerContext}
+	}
+	return QueryRowWithContext(ctx, queryRower, b)
+}
+
+// Scan is a shortcut for QueryRow().Scan.
+func (b *CompoundBuilder) Scan(dest ...interface{}) error {
+	return b.QueryRow().Scan(dest...)
+}
+
+// PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
+// query.
+func (b *CompoundBuilder) PlaceholderFormat(f PlaceholderFormat) *CompoundBuilder {
+	b.placeholderFormat = f
+	return b
+}
+
+// Dialect sets Dialect (e.g. Postgres or SQLServer) for the query.
+func (b *CompoundBuilder) Dialect(d Dialect) *CompoundBuilder {
+	b.dialect = d
+	return b
+}
+
+// BindArrays sets whether slice values of Eq and Neq are bound as a single
+// array parameter, see Array.
+func (b *CompoundBuilder) BindArrays(enabled bool) *CompoundBuilder {
+	b.bindArrays = enabled
+	return b
+}
+
+// ToSQL builds the query into a SQL string and bound args.
+func (b *CompoundBuilder) ToSQL() (sqlStr string, args []interface{}, err error) {
+	sql := &bytes.Buffer{}
+	args, err = b.WriteSQL(sql)
+	if err != nil {
+		return
+	}
+
+	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
+	args = namedArgsLast(args)
+	return
+}
+
+// WriteSQL implements Sqlizer, so the compound statement can be used as a
+// subquery.
+func (b *CompoundBuilder) WriteSQL(sql *bytes.Buffer) (args []interface{}, err error) {
+	return b.writeDialectSQL(sql, dialectOptions{})
+}
+
+func (b *CompoundBuilder) writeDialectSQL(sql *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	d = b.renderOptions(d)
+
+	if len(b.parts) < 2 {
+		err = errors.New("compound statements must have at least two select statements")
+		return
+	}
+
+	for i, p := range b.parts {
+		if i > 0 {
+			sql.WriteString(" " + p.op + " ")
+		}
+
+		// ORDER BY and LIMIT of a single select must be enclosed in parentheses,
+		// otherwise they apply to the whole compound statement. SQLite doesn't
+		// accept parenthesized selects there, so it gets a derived table.
+		s := p.selectB
+		parens := len(s.orderBys) > 0 || s.limitValid || s.offsetValid
+		if parens && d.Dialect == SQLite {
+			sql.WriteString("SELECT * FROM (")
+		} else if parens {
+			sql.WriteByte('(')
+		}
+
+		args, err = appendToSQL([]Sqlizer{s}, sql, "", d, args)
+		if err != nil {
+			return
+		}
+
+		if parens {
+			sql.WriteByte(')')
+		}
+	}
+
+	if len(b.orderBys) > 0 {
+		sql.WriteString(" ORDER BY " + strings.Join(b.orderBys, ", "))
+	}
+
+	b.writeSelectSQL(sql, d.Dialect, len(b.orderBys) > 0, false)
+
+	return
+}
+
+func (b *CompoundBuilder) add(op string, selects []*SelectBuilder) *CompoundBuilder {
+	for _, s := range selects {
+		b.parts = append(b.parts, compoundPart{op: op, selectB: s})
+	}
+	return b
+}
+
+// Union adds select statements combined with UNION.
+func (b *CompoundBuilder) Union(selects ...*SelectBuilder) *CompoundBuilder {
+	return b.add("UNION", selects)
+}
+
+// UnionAll adds select statements combined with UNION ALL.
+func (b *CompoundBuilder) UnionAll(selects ...*SelectBuilder) *CompoundBuilder {
+	return b.add("UNION ALL", selects)
+}
+
+// Intersect adds select statements combined with INTERSECT.
+func (b *CompoundBuilder) Intersect(selects ...*SelectBuilder) *CompoundBuilder {
+	return b.add("INTERSECT", selects)
+}
+
+// Except adds select statements combined with EXCEPT.
+func (b *CompoundBuilder) Except(selects ...*SelectBuilder) *CompoundBuilder {
+	return b.add("EXCEPT", selects)
+}
+
+// OrderBy adds ORDER BY expressions applied to the whole result.
+func (b *CompoundBuilder) OrderBy(orderBys ...string) *CompoundBuilder {
+	b.orderBys = append(b.orderBys, orderBys...)
+	return b
+}
+
+// Limit sets a LIMIT clause applied to the whole result.
+func (b *CompoundBuilder) Limit(limit uint64) *CompoundBuilder {
+	b.limit = limit
+	b.limitValid = true
+	return b
+}
+
+// Offset sets a OFFSET clause applied to the whole result.
+func (b *CompoundBuilder) Offset(offset uint64) *CompoundBuilder {
+	b.offset = offset
+	b.offsetValid = true
+	return b
+}
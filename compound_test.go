@@ -0,0 +1,125 @@
+package sqrl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompoundBuilderToSQL(t *testing.T) {
+	b := Union(
+		Select("a").From("b").Where("c = ?", 1),
+		Select("a").From("d").Where("e = ?", 2),
+	).
+		UnionAll(Select("a").From("f").OrderBy("a").Limit(3)).
+		Except(Select("a").From("g").Where(Eq{"h": []int{4, 5}})).
+		OrderBy("a DESC").
+		Limit(10).
+		Offset(20)
+
+	sql, args, err := b.PlaceholderFormat(Dollar).ToSQL()
+	assert.NoError(t, err)
+
+	expectedSQL := "SELECT a FROM b WHERE c = $1 " +
+		"UNION SELECT a FROM d WHERE e = $2 " +
+		"UNION ALL (SELECT a FROM f ORDER BY a LIMIT 3) " +
+		"EXCEPT SELECT a FROM g WHERE h IN ($3,$4) " +
+		"ORDER BY a DESC LIMIT 10 OFFSET 20"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []interface{}{1, 2, 4, 5}, args)
+
+	sql, _, err = b.Dialect(SQLite).PlaceholderFormat(Question).ToSQL()
+	assert.NoError(t, err)
+
+	expectedSQL = "SELECT a FROM b WHERE c = ? " +
+		"UNION SELECT a FROM d WHERE e = ? " +
+		"UNION ALL SELECT * FROM (SELECT a FROM f ORDER BY a LIMIT 3) " +
+		"EXCEPT SELECT a FROM g WHERE h IN (?,?) " +
+		"ORDER BY a DESC LIMIT 10 OFFSET 20"
+	assert.Equal(t, expectedSQL, sql)
+}
+
+func TestCompoundBuilderIntersect(t *testing.T) {
+	sql, _, err := Intersect(Select("a").From("b"), Select("a").From("c")).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b INTERSECT SELECT a FROM c", sql)
+}
+
+func TestSelectBuilderCompound(t *testing.T) {
+	sql, args, err := Select("a").From("t1").Where("x = ?", 1).
+		Union(Select("a").From("t2"), Select("a").From("t3")).
+		Dialect(Postgres).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM t1 WHERE x = $1 UNION SELECT a FROM t2 UNION SELECT a FROM t3", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, _, err = Select("a").From("t1").UnionAll(Select("a").From("t2")).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM t1 UNION ALL SELECT a FROM t2", sql)
+
+	sql, _, err = Select("a").From("t1").Intersect(Select("a").From("t2")).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM t1 INTERSECT SELECT a FROM t2", sql)
+
+	sql, _, err = Select("a").From("t1").Except(Select("a").From("t2")).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM t1 EXCEPT SELECT a FROM t2", sql)
+}
+
+func TestCompoundBuilderSubquery(t *testing.T) {
+	ids := UnionAll(
+		Select("id").From("a").Where("x = ?", 1),
+		Select("id").From("b").Where("y = ?", 2),
+	)
+
+	sql, args, err := Select("*").
+		From("c").
+		Where("d = ?", 0).
+		Where(Expr("id IN (?)", ids)).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM c WHERE d = $1 AND id IN (SELECT id FROM a WHERE x = $2 UNION ALL SELECT id FROM b WHERE y = $3)", sql)
+	assert.Equal(t, []interface{}{0, 1, 2}, args)
+}
+
+func TestCompoundBuilderErrors(t *testing.T) {
+	_, _, err := Union(Select("a")).ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = Union(Select("a"), Select()).ToSQL()
+	assert.Error(t, err)
+}
+
+func TestCompoundBuilderRunners(t *testing.T) {
+	db := &DBStub{}
+	b := Union(Select("a").From("b"), Select("a").From("c")).RunWith(db)
+
+	expectedSQL := "SELECT a FROM b UNION SELECT a FROM c"
+
+	b.Query()
+	assert.Equal(t, expectedSQL, db.LastQuerySql)
+
+	b.QueryContext(context.TODO())
+	assert.Equal(t, expectedSQL, db.LastQuerySql)
+
+	b.QueryRow()
+	assert.Equal(t, expectedSQL, db.LastQueryRowSql)
+
+	err := b.Scan()
+	assert.NoError(t, err)
+}
+
+func TestCompoundBuilderNoRunner(t *testing.T) {
+	b := Union(Select("a").From("b"), Select("a").From("c"))
+
+	_, err := b.Query()
+	assert.Equal(t, ErrRunnerNotSet, err)
+
+	err = b.Scan()
+	assert.Equal(t, ErrRunnerNotSet, err)
+}
@@ -0,0 +1,150 @@
+package sqrl
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// OnConflictBuilder builds PostgreSQL "ON CONFLICT" clause of INSERT
+// statements. Use it with InsertBuilder.OnConflict.
+type OnConflictBuilder struct {
+	columns    []string
+	constraint string
+	whereParts []Sqlizer
+
+	doNothing        bool
+	setClauses       []setClause
+	updateWhereParts []Sqlizer
+}
+
+// OnConflict returns a new OnConflictBuilder with conflict target columns.
+// Columns may be omitted for "ON CONFLICT DO NOTHING".
+func OnConflict(columns ...string) *OnConflictBuilder {
+	return &OnConflictBuilder{columns: columns}
+}
+
+// OnConstraint returns a new OnConflictBuilder using named constraint as
+// conflict target: "ON CONFLICT ON CONSTRAINT name".
+func OnConstraint(name string) *OnConflictBuilder {
+	return &OnConflictBuilder{constraint: name}
+}
+
+// Excluded returns a reference to the value proposed for insertion, i.e.
+// "EXCLUDED.column". Useful as a value of OnConflictBuilder.Set.
+func Excluded(column string) Sqlizer {
+	return Expr("EXCLUDED." + column)
+}
+
+// Where adds index predicate to the conflict target, which allows to infer
+// partial unique indexes.
+//
+// See SelectBuilder.Where for more information.
+func (b *OnConflictBuilder) Where(pred interface{}, args ...interface{}) *OnConflictBuilder {
+	b.whereParts = append(b.whereParts, newWherePart(pred, args...))
+	return b
+}
+
+// DoNothing sets "DO NOTHING" conflict action. It is also used when no Set
+// clauses are added.
+func (b *OnConflictBuilder) DoNothing() *OnConflictBuilder {
+	b.doNothing = true
+	return b
+}
+
+// Set adds a SET clause to the "DO UPDATE" conflict action.
+//
+// See UpdateBuilder.Set.
+func (b *OnConflictBuilder) Set(column string, value interface{}) *OnConflictBuilder {
+	b.setClauses = addSetClause(b.setClauses, column, value)
+	return b
+}
+
+// SetMap is a convenience method which calls .Set for each key/value pair in clauses.
+// The pairs are added in the order of sorted keys.
+func (b *OnConflictBuilder) SetMap(clauses map[string]interface{}) *OnConflictBuilder {
+	for _, p := range sortedPairs(clauses) {
+		b.Set(p.Column, p.Value)
+	}
+
+	return b
+}
+
+// SetExcluded sets each column to the value proposed for insertion:
+// "column = EXCLUDED.column".
+func (b *OnConflictBuilder) SetExcluded(columns ...string) *OnConflictBuilder {
+	for _, column := range columns {
+		b.Set(column, Excluded(column))
+	}
+
+	return b
+}
+
+// UpdateWhere adds WHERE expressions to the "DO UPDATE" conflict action.
+//
+// See SelectBuilder.Where for more information.
+func (b *OnConflictBuilder) UpdateWhere(pred interface{}, args ...interface{}) *OnConflictBuilder {
+	b.updateWhereParts = append(b.updateWhereParts, newWherePart(pred, args...))
+	return b
+}
+
+// WriteSQL implements Sqlizer
+func (b *OnConflictBuilder) WriteSQL(sql *bytes.Buffer) (args []interface{}, err error) {
+	return b.writeDialectSQL(sql, dialectOptions{})
+}
+
+func (b *OnConflictBuilder) writeDialectSQL(sql *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	if b.doNothing && len(b.setClauses) > 0 {
+		err = errors.New("on conflict clause cannot have both DO NOTHING and Set clauses")
+		return
+	}
+	if len(b.whereParts) > 0 && len(b.columns) == 0 {
+		err = errors.New("on conflict index predicate requires conflict target columns")
+		return
+	}
+	if len(b.setClauses) > 0 && len(b.columns) == 0 && len(b.constraint) == 0 {
+		err = errors.New("on conflict DO UPDATE requires conflict target")
+		return
+	}
+	if len(b.updateWhereParts) > 0 && len(b.setClauses) == 0 {
+		err = errors.New("on conflict UpdateWhere requires at least one Set clause")
+		return
+	}
+
+	sql.WriteString("ON CONFLICT")
+
+	if len(b.constraint) > 0 {
+		sql.WriteString(" ON CONSTRAINT ")
+		sql.WriteString(b.constraint)
+	} else if len(b.columns) > 0 {
+		sql.WriteString(" (")
+		sql.WriteString(strings.Join(b.columns, ","))
+		sql.WriteString(")")
+	}
+
+	if len(b.whereParts) > 0 {
+		sql.WriteString(" WHERE ")
+		args, err = appendToSQL(b.whereParts, sql, " AND ", d, args)
+		if err != nil {
+			return
+		}
+	}
+
+	if len(b.setClauses) == 0 {
+		sql.WriteString(" DO NOTHING")
+		return
+	}
+
+	sql.WriteString(" DO UPDATE SET ")
+	args, err = appendSetClausesToSQL(sql, b.setClauses, d, args)
+	if err != nil {
+		return
+	}
+
+	if len(b.updateWhereParts) > 0 {
+		sql.WriteString(" WHERE ")
+		args, err = appendToSQL(b.updateWhereParts, sql, " AND ", d, args)
+	}
+
+	return
+}
@@ -0,0 +1,81 @@
+package sqrl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnConflictToSQL(t *testing.T) {
+	tests := []struct {
+		c    *OnConflictBuilder
+		sql  string
+		args []interface{}
+	}{
+		{OnConflict(), "ON CONFLICT DO NOTHING", nil},
+		{OnConflict("a", "b").DoNothing(), "ON CONFLICT (a,b) DO NOTHING", nil},
+		{OnConstraint("a_pkey"), "ON CONFLICT ON CONSTRAINT a_pkey DO NOTHING", nil},
+		{
+			OnConflict("a").Where("deleted_at IS NULL").SetExcluded("b", "c"),
+			"ON CONFLICT (a) WHERE deleted_at IS NULL DO UPDATE SET b = EXCLUDED.b, c = EXCLUDED.c",
+			nil,
+		},
+		{
+			OnConstraint("a_pkey").Set("b", 1).Set("c", Expr("c + ?", 2)).UpdateWhere(Neq{"d": 3}),
+			"ON CONFLICT ON CONSTRAINT a_pkey DO UPDATE SET b = ?, c = c + ? WHERE d <> ?",
+			[]interface{}{1, 2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		b := &bytes.Buffer{}
+		args, err := test.c.WriteSQL(b)
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, b.String())
+		assert.Equal(t, test.args, args)
+	}
+}
+
+func TestOnConflictErrors(t *testing.T) {
+	tests := []*OnConflictBuilder{
+		OnConflict("a").DoNothing().Set("b", 1),
+		OnConflict().Where("b"),
+		OnConflict().Set("b", 1),
+		OnConflict("a").UpdateWhere("b"),
+	}
+
+	for _, c := range tests {
+		_, err := c.WriteSQL(&bytes.Buffer{})
+		assert.Error(t, err)
+	}
+}
+
+func TestInsertBuilderOnConflict(t *testing.T) {
+	sql, args, err := Insert("a").
+		Columns("id", "b", "c").
+		Values(1, 2, 3).
+		OnConflict(OnConflict("id").Where("d = ?", 4).SetExcluded("b").Set("c", Expr("a.c + ?", 5)).UpdateWhere("a.e <> ?", 6)).
+		Suffix("RETURNING id").
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	expectedSQL := "INSERT INTO a (id,b,c) VALUES ($1,$2,$3) " +
+		"ON CONFLICT (id) WHERE d = $4 DO UPDATE SET b = EXCLUDED.b, c = a.c + $5 WHERE a.e <> $6 " +
+		"RETURNING id"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5, 6}, args)
+
+	_, _, err = Insert("a").Values(1).OnConflict(OnConflict().Set("b", 1)).ToSQL()
+	assert.Error(t, err)
+
+	sql, _, err = Insert("a").Values(1).OnConflict(OnConflict("a")).Dialect(SQLite).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a VALUES (?) ON CONFLICT (a) DO NOTHING", sql)
+
+	for _, d := range []Dialect{MySQL, SQLServer, Oracle} {
+		_, _, err = Insert("a").Values(1).OnConflict(OnConflict("a")).Dialect(d).ToSQL()
+		assert.Error(t, err)
+	}
+}
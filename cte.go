@@ -0,0 +1,62 @@
+package sqrl
+
+import (
+	"bytes"
+	"strings"
+)
+
+// cte is a common table expression, i.e. a named query of WITH clause.
+type cte struct {
+	name      string
+	columns   []string
+	recursive bool
+	query     Sqlizer
+}
+
+// appendCTEsToSQL writes "WITH [RECURSIVE] name [(columns)] AS (query), ..."
+// clause followed by a space.
+func appendCTEsToSQL(b *bytes.Buffer, ctes []cte, d dialectOptions, args []interface{}) ([]interface{}, error) {
+	b.WriteString("WITH ")
+
+	// SQL Server and Oracle detect recursive queries without the keyword and
+	// don't accept it
+	if d.Dialect != SQLServer && d.Dialect != Oracle {
+		for _, c := range ctes {
+			if c.recursive {
+				b.WriteString("RECURSIVE ")
+				break
+			}
+		}
+	}
+
+	for i, c := range ctes {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString(c.name)
+
+		if len(c.columns) > 0 {
+			b.WriteString("(")
+			b.WriteString(strings.Join(c.columns, ", "))
+			b.WriteString(")")
+		}
+
+		b.WriteString(" AS (")
+
+		queryArgs, err := writeSQL(b, c.query, d)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(queryArgs) != 0 {
+			args = append(args, queryArgs...)
+		}
+
+		b.WriteString(")")
+	}
+
+	b.WriteString(" ")
+
+	return args, nil
+}
@@ -0,0 +1,109 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectBuilderWith(t *testing.T) {
+	sql, args, err := Select("*").
+		With("a", Select("id").From("t1").Where("x = ?", 1)).
+		With("b", Select("id").From("t2").Where("y = ?", 2)).
+		From("a").
+		Join("b USING (id)").
+		Where("z = ?", 3).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	expectedSQL := "WITH a AS (SELECT id FROM t1 WHERE x = $1), b AS (SELECT id FROM t2 WHERE y = $2) " +
+		"SELECT * FROM a JOIN b USING (id) WHERE z = $3"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []interface{}{1, 2, 3}, args)
+}
+
+func TestSelectBuilderWithRecursive(t *testing.T) {
+	tree := UnionAll(
+		Select("id", "parent_id").From("nodes").Where("id = ?", 1),
+		Select("n.id", "n.parent_id").From("nodes n").Join("tree t ON n.parent_id = t.id"),
+	)
+
+	b := Select("id").
+		WithRecursive("tree", []string{"id", "parent_id"}, tree).
+		From("tree")
+
+	sql, args, err := b.ToSQL()
+	assert.NoError(t, err)
+	expectedSQL := "WITH RECURSIVE tree(id, parent_id) AS (" +
+		"SELECT id, parent_id FROM nodes WHERE id = ? " +
+		"UNION ALL SELECT n.id, n.parent_id FROM nodes n JOIN tree t ON n.parent_id = t.id" +
+		") SELECT id FROM tree"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, _, err = b.Dialect(SQLServer).ToSQL()
+	assert.NoError(t, err)
+	assert.Contains(t, sql, "WITH tree(id, parent_id) AS (")
+}
+
+func TestDataModifyingWith(t *testing.T) {
+	moved := Delete("a").Where("created_at < ?", 1).Returning("*")
+
+	sql, args, err := Insert("b").
+		With("moved", moved).
+		Select(Select("*").From("moved").Where("x = ?", 2)).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "WITH moved AS (DELETE FROM a WHERE created_at < $1 RETURNING *) INSERT INTO b SELECT * FROM moved WHERE x = $2", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	sql, args, err = Update("c").
+		With("n", Insert("d").Values(3).Returning("id")).
+		Set("d_id", Expr("(SELECT id FROM n)")).
+		Where("id = ?", 4).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "WITH n AS (INSERT INTO d VALUES (?) RETURNING id) UPDATE c SET d_id = (SELECT id FROM n) WHERE id = ?", sql)
+	assert.Equal(t, []interface{}{3, 4}, args)
+
+	sql, args, err = Delete("e").
+		With("old", Select("id").From("f").Where("g = ?", 5)).
+		Where("id IN (SELECT id FROM old)").
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "WITH old AS (SELECT id FROM f WHERE g = ?) DELETE FROM e WHERE id IN (SELECT id FROM old)", sql)
+	assert.Equal(t, []interface{}{5}, args)
+}
+
+func TestInsertWithDialects(t *testing.T) {
+	q := Insert("t").
+		Columns("a").
+		With("x", Select("a").From("s").Where("b = ?", 1)).
+		Select(Select("a").From("x").Where("c = ?", 2))
+
+	sql, args, err := q.Dialect(MySQL).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO t (a) WITH x AS (SELECT a FROM s WHERE b = ?) SELECT a FROM x WHERE c = ?", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	sql, _, err = q.Dialect(Oracle).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO t (a) WITH x AS (SELECT a FROM s WHERE b = ?) SELECT a FROM x WHERE c = ?", sql)
+
+	sql, _, err = q.Dialect(SQLite).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "WITH x AS (SELECT a FROM s WHERE b = ?) INSERT INTO t (a) SELECT a FROM x WHERE c = ?", sql)
+
+	_, _, err = Insert("t").With("x", Select("1")).Values(1).Dialect(MySQL).ToSQL()
+	assert.Error(t, err)
+}
+
+func TestWithError(t *testing.T) {
+	_, _, err := Select("*").With("a", Select()).From("a").ToSQL()
+	assert.Error(t, err)
+}
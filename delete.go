@@ -5,7 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"strconv"
+	"fmt"
 	"strings"
 )
 
@@ -15,17 +15,18 @@ import (
 type DeleteBuilder struct {
 	StatementBuilderType
 
+	ctes       []cte
 	prefixes   []expr
 	what       []string
 	from       string
-	joins      []string
-	whereParts []sqlWriter
+	fromSource Sqlizer
+	joins      []Sqlizer
+	whereParts []Sqlizer
 	orderBys   []string
 
-	limit       uint64
-	limitValid  bool
-	offset      uint64
-	offsetValid bool
+	pagination
+
+	returning []string
 
 	suffixes []expr
 }
@@ -54,6 +55,41 @@ func (b *DeleteBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
 	return ExecWithContext(ctx, b.runWith, b)
 }
 
+// Query builds and Querys the query with the Runner set by RunWith.
+func (b *DeleteBuilder) Query() (*sql.Rows, error) {
+	return b.QueryContext(context.Background())
+}
+
+// QueryContext builds and runs the query using given context and Query command.
+func (b *DeleteBuilder) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	return QueryWithContext(ctx, b.runWith, b)
+}
+
+// QueryRow builds and QueryRows the query with the Runner set by RunWith.
+func (b *DeleteBuilder) QueryRow() RowScanner {
+	return b.QueryRowContext(context.Background())
+}
+
+// QueryRowContext builds and runs the query using given context.
+func (b *DeleteBuilder) QueryRowContext(ctx context.Context) RowScanner {
+	if b.runWith == nil {
+		return &Row{err: ErrRunnerNotSet}
+	}
+	queryRower, ok := b.runWith.(QueryRowerContext)
+	if !ok {
+		return &Row{err: ErrRunnerNotQueryRunnerContext}
+	}
+	return QueryRowWithContext(ctx, queryRower, b)
+}
+
+// Scan is a shortcut for QueryRow().Scan.
+func (b *DeleteBuilder) Scan(dest ...interface{}) error {
+	return b.QueryRow().Scan(dest...)
+}
+
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
 func (b *DeleteBuilder) PlaceholderFormat(f PlaceholderFormat) *DeleteBuilder {
@@ -61,68 +97,183 @@ func (b *DeleteBuilder) PlaceholderFormat(f PlaceholderFormat) *DeleteBuilder {
 	return b
 }
 
+// Dialect sets Dialect (e.g. Postgres or SQLServer) for the query.
+func (b *DeleteBuilder) Dialect(d Dialect) *DeleteBuilder {
+	b.dialect = d
+	return b
+}
+
+// BindArrays sets whether slice values of Eq and Neq are bound as a single
+// array parameter, see Array.
+func (b *DeleteBuilder) BindArrays(enabled bool) *DeleteBuilder {
+	b.bindArrays = enabled
+	return b
+}
+
 // ToSQL builds the query into a SQL string and bound args.
 func (b *DeleteBuilder) ToSQL() (sqlStr string, args []interface{}, err error) {
-	if len(b.from) == 0 {
+	sql := &bytes.Buffer{}
+	args, err = b.WriteSQL(sql)
+	if err != nil {
+		return
+	}
+
+	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
+	args = namedArgsLast(args)
+	return
+}
+
+// WriteSQL implements Sqlizer, so the statement can be used within other
+// queries, e.g. as a data-modifying WITH query.
+func (b *DeleteBuilder) WriteSQL(sql *bytes.Buffer) (args []interface{}, err error) {
+	return b.writeDialectSQL(sql, dialectOptions{})
+}
+
+func (b *DeleteBuilder) writeDialectSQL(sql *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	d = b.renderOptions(d)
+
+	if len(b.from) == 0 && b.fromSource == nil {
 		err = errors.New("delete statements must specify a From table")
 		return
 	}
 
-	sql := &bytes.Buffer{}
+	if b.fromSource != nil {
+		switch {
+		case d.Dialect == Postgres || d.Dialect == SQLite || d.Dialect == Oracle:
+			err = fmt.Errorf("%s dialect does not support DELETE from a subquery", d.Dialect)
+			return
+		case len(b.what) == 0:
+			err = errors.New("delete from a subquery must specify What tables")
+			return
+		}
+	}
+
+	if err = checkReturning(b.returning, d.Dialect); err != nil {
+		return
+	}
+
+	if err = b.checkModify(d.Dialect, "DELETE", len(b.orderBys) > 0); err != nil {
+		return
+	}
 
 	if len(b.prefixes) > 0 {
-		args, _ = appendExpressionsToSQL(sql, b.prefixes, " ", args)
+		args, err = appendExpressionsToSQL(sql, b.prefixes, " ", d, args)
+		if err != nil {
+			return
+		}
 		sql.WriteString(" ")
 	}
 
+	if len(b.ctes) > 0 {
+		args, err = appendCTEsToSQL(sql, b.ctes, d, args)
+		if err != nil {
+			return
+		}
+	}
+
 	sql.WriteString("DELETE ")
+
+	top := b.useTop(d.Dialect)
+	if top {
+		b.writeTop(sql)
+	}
+
 	// following condition helps to avoid duplicate "from" value in DELETE query
 	// e.g. "DELETE a FROM a ..." which is valid for MySQL but not for PostgreSQL
-	if len(b.what) > 0 && (len(b.what) != 1 || b.what[0] != b.from) {
+	hasWhat := len(b.what) > 0 && (len(b.what) != 1 || b.what[0] != b.from || b.fromSource != nil)
+	output := len(b.returning) > 0 && d.Dialect == SQLServer
+
+	if hasWhat {
 		sql.WriteString(strings.Join(b.what, ", "))
 		sql.WriteString(" ")
+	} else if output && len(b.joins) > 0 {
+		// OUTPUT must follow the target table, which then can't be written
+		// as a part of FROM clause: "DELETE a OUTPUT ... FROM a JOIN ..."
+		sql.WriteString(b.from)
+		sql.WriteString(" ")
+	}
+
+	if output && (hasWhat || len(b.joins) > 0) {
+		appendOutputToSQL(sql, b.returning, "DELETED")
+		sql.WriteString(" ")
 	}
 
 	sql.WriteString("FROM ")
-	sql.WriteString(b.from)
+	if b.fromSource != nil {
+		args, err = appendToSQL([]Sqlizer{b.fromSource}, sql, "", d, args)
+		if err != nil {
+			return
+		}
+	} else {
+		sql.WriteString(b.from)
+	}
+
+	if output && !hasWhat && len(b.joins) == 0 {
+		sql.WriteString(" ")
+		appendOutputToSQL(sql, b.returning, "DELETED")
+	}
 
 	if len(b.joins) > 0 {
 		sql.WriteString(" ")
-		sql.WriteString(strings.Join(b.joins, " "))
+		args, err = appendToSQL(b.joins, sql, " ", d, args)
+		if err != nil {
+			return
+		}
 	}
 
 	if len(b.whereParts) > 0 {
 		sql.WriteString(" WHERE ")
-		args, err = appendToSQL(b.whereParts, sql, " AND ", args)
+		args, err = appendToSQL(b.whereParts, sql, " AND ", d, args)
 		if err != nil {
 			return
 		}
 	}
 
+	if len(b.returning) > 0 && d.Dialect == SQLite {
+		sql.WriteString(" ")
+		appendReturningToSQL(sql, b.returning)
+	}
+
 	if len(b.orderBys) > 0 {
 		sql.WriteString(" ORDER BY ")
 		sql.WriteString(strings.Join(b.orderBys, ", "))
 	}
 
-	if b.limitValid {
-		sql.WriteString(" LIMIT ")
-		sql.WriteString(strconv.FormatUint(b.limit, 10))
+	if !top {
+		b.writeLimitSQL(sql)
 	}
 
-	if b.offsetValid {
-		sql.WriteString(" OFFSET ")
-		sql.WriteString(strconv.FormatUint(b.offset, 10))
+	if len(b.returning) > 0 && d.Dialect != SQLite && d.Dialect != SQLServer {
+		sql.WriteString(" ")
+		appendReturningToSQL(sql, b.returning)
 	}
 
 	if len(b.suffixes) > 0 {
 		sql.WriteString(" ")
-		args, _ = appendExpressionsToSQL(sql, b.suffixes, " ", args)
+		args, err = appendExpressionsToSQL(sql, b.suffixes, " ", d, args)
+		if err != nil {
+			return
+		}
 	}
 
-	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
 	return
 }
 
+// With adds a named query to WITH clause of the query. The query can be
+// any Sqlizer, e.g. SelectBuilder or a data-modifying statement like
+// InsertBuilder. Its args precede the args of the main query.
+func (b *DeleteBuilder) With(name string, query Sqlizer) *DeleteBuilder {
+	b.ctes = append(b.ctes, cte{name: name, query: query})
+	return b
+}
+
+// WithRecursive adds a recursive named query with optional column names to
+// WITH clause of the query, usually a CompoundBuilder.
+func (b *DeleteBuilder) WithRecursive(name string, columns []string, query Sqlizer) *DeleteBuilder {
+	b.ctes = append(b.ctes, cte{name: name, columns: columns, recursive: true, query: query})
+	return b
+}
+
 // Prefix adds an expression to the beginning of the query
 func (b *DeleteBuilder) Prefix(sql string, args ...interface{}) *DeleteBuilder {
 	b.prefixes = append(b.prefixes, Expr(sql, args...))
@@ -132,6 +283,32 @@ func (b *DeleteBuilder) Prefix(sql string, args ...interface{}) *DeleteBuilder {
 // From sets the FROM clause of the query.
 func (b *DeleteBuilder) From(from string) *DeleteBuilder {
 	b.from = from
+	b.fromSource = nil
+	return b
+}
+
+// FromSelect sets a subquery as the FROM clause of a multi-table delete:
+// "DELETE what FROM (SELECT ...) AS alias JOIN ...". Rows are deleted from
+// the What tables, which are usually joined to the subquery. Only Generic,
+// MySQL and SQLServer dialects support it.
+// Ex:
+//
+//	Delete("t").
+//		FromSelect(Select("id").From("stale"), "s").
+//		Join("t ON t.id = s.id")
+func (b *DeleteBuilder) FromSelect(from *SelectBuilder, alias string) *DeleteBuilder {
+	b.from = ""
+	b.fromSource = derivedTable{query: from, alias: alias}
+	return b
+}
+
+// FromValues sets a VALUES list with optional column names as the FROM
+// clause of a multi-table delete: "FROM (VALUES (?,?)) AS alias(columns)".
+//
+// See FromSelect.
+func (b *DeleteBuilder) FromValues(values *ValuesBuilder, alias string, columns ...string) *DeleteBuilder {
+	b.from = ""
+	b.fromSource = derivedTable{query: values, alias: alias, columns: columns}
 	return b
 }
 
@@ -145,7 +322,7 @@ func (b *DeleteBuilder) What(what ...string) *DeleteBuilder {
 	}
 
 	b.what = filteredWhat
-	if len(filteredWhat) == 1 {
+	if len(filteredWhat) == 1 && b.fromSource == nil {
 		b.From(filteredWhat[0])
 	}
 
@@ -164,14 +341,16 @@ func (b *DeleteBuilder) OrderBy(orderBys ...string) *DeleteBuilder {
 	return b
 }
 
-// Limit sets a LIMIT clause on the query.
+// Limit sets a LIMIT clause on the query. It is rendered as "TOP (n)" for
+// SQLServer dialect, while Postgres and Oracle dialects return an error.
 func (b *DeleteBuilder) Limit(limit uint64) *DeleteBuilder {
 	b.limit = limit
 	b.limitValid = true
 	return b
 }
 
-// Offset sets a OFFSET clause on the query.
+// Offset sets a OFFSET clause on the query. Only Generic and SQLite dialects
+// support it.
 func (b *DeleteBuilder) Offset(offset uint64) *DeleteBuilder {
 	b.offset = offset
 	b.offsetValid = true
@@ -179,6 +358,14 @@ func (b *DeleteBuilder) Offset(offset uint64) *DeleteBuilder {
 	return b
 }
 
+// Returning adds columns to RETURNING clause of the query. The clause is
+// rendered as "OUTPUT DELETED.column" for SQLServer dialect.
+// MySQL and Oracle dialects return an error.
+func (b *DeleteBuilder) Returning(columns ...string) *DeleteBuilder {
+	b.returning = append(b.returning, columns...)
+	return b
+}
+
 // Suffix adds an expression to the end of the query
 func (b *DeleteBuilder) Suffix(sql string, args ...interface{}) *DeleteBuilder {
 	b.suffixes = append(b.suffixes, Expr(sql, args...))
@@ -186,24 +373,75 @@ func (b *DeleteBuilder) Suffix(sql string, args ...interface{}) *DeleteBuilder {
 	return b
 }
 
-// JoinClause adds a join clause to the query.
-func (b *DeleteBuilder) JoinClause(join string) *DeleteBuilder {
-	b.joins = append(b.joins, join)
+// JoinClause adds a join clause to the query. The clause is either a string
+// with optional args bound to its placeholders, or a Sqlizer.
+func (b *DeleteBuilder) JoinClause(join interface{}, args ...interface{}) *DeleteBuilder {
+	if join != nil {
+		b.joins = append(b.joins, newPart(join, args...))
+	}
 
 	return b
 }
 
 // Join adds a JOIN clause to the query.
-func (b *DeleteBuilder) Join(join string) *DeleteBuilder {
-	return b.JoinClause("JOIN " + join)
+func (b *DeleteBuilder) Join(join string, args ...interface{}) *DeleteBuilder {
+	return b.JoinClause("JOIN "+join, args...)
 }
 
 // LeftJoin adds a LEFT JOIN clause to the query.
-func (b *DeleteBuilder) LeftJoin(join string) *DeleteBuilder {
-	return b.JoinClause("LEFT JOIN " + join)
+func (b *DeleteBuilder) LeftJoin(join string, args ...interface{}) *DeleteBuilder {
+	return b.JoinClause("LEFT JOIN "+join, args...)
 }
 
 // RightJoin adds a RIGHT JOIN clause to the query.
-func (b *DeleteBuilder) RightJoin(join string) *DeleteBuilder {
-	return b.JoinClause("RIGHT JOIN " + join)
+func (b *DeleteBuilder) RightJoin(join string, args ...interface{}) *DeleteBuilder {
+	return b.JoinClause("RIGHT JOIN "+join, args...)
+}
+
+// JoinOn adds a "JOIN table ON on" clause to the query. The table is either a
+// string or a Sqlizer, the on condition accepts the same types as Where.
+//
+// Ex:
+//
+//	.JoinOn("emails e", And{Expr("e.user_id = u.id"), Eq{"e.primary": true}})
+func (b *DeleteBuilder) JoinOn(table interface{}, on interface{}, args ...interface{}) *DeleteBuilder {
+	b.joins = append(b.joins, newJoinPart("JOIN", table, on, args...))
+	return b
+}
+
+// LeftJoinOn adds a "LEFT JOIN table ON on" clause to the query.
+//
+// See JoinOn.
+func (b *DeleteBuilder) LeftJoinOn(table interface{}, on interface{}, args ...interface{}) *DeleteBuilder {
+	b.joins = append(b.joins, newJoinPart("LEFT JOIN", table, on, args...))
+	return b
+}
+
+// RightJoinOn adds a "RIGHT JOIN table ON on" clause to the query.
+//
+// See JoinOn.
+func (b *DeleteBuilder) RightJoinOn(table interface{}, on interface{}, args ...interface{}) *DeleteBuilder {
+	b.joins = append(b.joins, newJoinPart("RIGHT JOIN", table, on, args...))
+	return b
+}
+
+// JoinSelect adds a "JOIN (SELECT ...) AS alias ON on" clause to the query.
+//
+// See JoinOn.
+func (b *DeleteBuilder) JoinSelect(join *SelectBuilder, alias string, on interface{}, args ...interface{}) *DeleteBuilder {
+	return b.JoinOn(derivedTable{query: join, alias: alias}, on, args...)
+}
+
+// LeftJoinSelect adds a "LEFT JOIN (SELECT ...) AS alias ON on" clause to the query.
+//
+// See JoinOn.
+func (b *DeleteBuilder) LeftJoinSelect(join *SelectBuilder, alias string, on interface{}, args ...interface{}) *DeleteBuilder {
+	return b.LeftJoinOn(derivedTable{query: join, alias: alias}, on, args...)
+}
+
+// RightJoinSelect adds a "RIGHT JOIN (SELECT ...) AS alias ON on" clause to the query.
+//
+// See JoinOn.
+func (b *DeleteBuilder) RightJoinSelect(join *SelectBuilder, alias string, on interface{}, args ...interface{}) *DeleteBuilder {
+	return b.RightJoinOn(derivedTable{query: join, alias: alias}, on, args...)
 }
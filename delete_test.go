@@ -93,6 +93,60 @@ func TestDeleteSqlMultipleTables(t *testing.T) {
 	assert.Equal(t, expectedArgs, args)
 }
 
+func TestDeleteBuilderJoins(t *testing.T) {
+	sql, args, err := Delete("a").
+		Join("b ON b.a_id = a.id AND b.x = ?", 1).
+		LeftJoinOn("c", Eq{"c.y": 2}).
+		RightJoinOn("d", "d.a_id = a.id").
+		JoinSelect(Select("id").From("e").Where("z = ?", 3), "e", "e.id = a.e_id").
+		LeftJoinSelect(Select("id").From("f"), "f", "f.id = a.f_id").
+		RightJoinSelect(Select("id").From("g"), "g", "g.id = a.g_id").
+		Where("a.w = ?", 4).
+		ToSQL()
+
+	assert.NoError(t, err)
+	expectedSQL := "DELETE FROM a " +
+		"JOIN b ON b.a_id = a.id AND b.x = ? " +
+		"LEFT JOIN c ON c.y = ? " +
+		"RIGHT JOIN d ON d.a_id = a.id " +
+		"JOIN (SELECT id FROM e WHERE z = ?) AS e ON e.id = a.e_id " +
+		"LEFT JOIN (SELECT id FROM f) AS f ON f.id = a.f_id " +
+		"RIGHT JOIN (SELECT id FROM g) AS g ON g.id = a.g_id " +
+		"WHERE a.w = ?"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, args)
+}
+
+func TestDeleteBuilderFromSelect(t *testing.T) {
+	sql, args, err := Delete("t").
+		FromSelect(Select("id").From("stale").Where("age > ?", 1), "s").
+		Join("t ON t.id = s.id").
+		Where("t.x = ?", 2).
+		Dialect(MySQL).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE t FROM (SELECT id FROM stale WHERE age > ?) AS s JOIN t ON t.id = s.id WHERE t.x = ?", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	sql, args, err = Delete().
+		FromValues(Values(1).Values(2), "v", "id").
+		What("t").
+		Join("t ON t.id = v.id").
+		Returning("id").
+		Dialect(SQLServer).
+		PlaceholderFormat(AtP).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE t OUTPUT DELETED.id FROM (VALUES (@p1),(@p2)) AS v(id) JOIN t ON t.id = v.id", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	_, _, err = Delete().FromSelect(Select("id").From("stale"), "s").ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = Delete("t").FromSelect(Select("id").From("stale"), "s").Dialect(Postgres).ToSQL()
+	assert.Error(t, err)
+}
+
 func TestDeleteBuilderZeroOffsetLimit(t *testing.T) {
 	qb := Delete("").
 		From("b").
@@ -134,12 +188,77 @@ func TestDeleteBuilderRunners(t *testing.T) {
 	assert.Equal(t, expectedSql, db.LastExecSql)
 }
 
+func TestDeleteBuilderQueryRunners(t *testing.T) {
+	db := &DBStub{}
+	b := Delete("test").Where("x = ?", 1).Returning("*").RunWith(db)
+
+	expectedSQL := "DELETE FROM test WHERE x = ? RETURNING *"
+
+	b.Query()
+	assert.Equal(t, expectedSQL, db.LastQuerySql)
+
+	b.QueryContext(context.TODO())
+	assert.Equal(t, expectedSQL, db.LastQuerySql)
+
+	b.QueryRow()
+	assert.Equal(t, expectedSQL, db.LastQueryRowSql)
+
+	err := b.Scan()
+	assert.NoError(t, err)
+}
+
+func TestDeleteBuilderReturning(t *testing.T) {
+	tests := []struct {
+		b   *DeleteBuilder
+		sql string
+	}{
+		{
+			Delete("a").Where("b = ?", 1).Returning("id"),
+			"DELETE FROM a WHERE b = ? RETURNING id",
+		},
+		{
+			Delete("a").Where("b = ?", 1).OrderBy("c").Limit(2).Returning("id").Dialect(SQLite),
+			"DELETE FROM a WHERE b = ? RETURNING id ORDER BY c LIMIT 2",
+		},
+		{
+			Delete("a").Where("b = ?", 1).Returning("id", "*").Dialect(SQLServer),
+			"DELETE FROM a OUTPUT DELETED.id, DELETED.* WHERE b = ?",
+		},
+		{
+			Delete("a").Join("b ON a.id = b.a_id").Returning("id").Dialect(SQLServer),
+			"DELETE a OUTPUT DELETED.id FROM a JOIN b ON a.id = b.a_id",
+		},
+		{
+			Delete("a1").From("a AS a1").Returning("id").Dialect(SQLServer),
+			"DELETE a1 OUTPUT DELETED.id FROM a AS a1",
+		},
+	}
+
+	for _, test := range tests {
+		sql, _, err := test.b.ToSQL()
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+	}
+
+	_, _, err := Delete("a").Returning("id").Dialect(Oracle).ToSQL()
+	assert.EqualError(t, err, "returning clause is not supported by Oracle dialect")
+
+	_, _, err = Delete("a").Returning("id").Dialect(MySQL).ToSQL()
+	assert.EqualError(t, err, "returning clause is not supported by MySQL dialect")
+}
+
 func TestDeleteBuilderNoRunner(t *testing.T) {
 	b := Delete("test")
 
 	_, err := b.Exec()
 	assert.Equal(t, ErrRunnerNotSet, err)
 
+	_, err = b.Query()
+	assert.Equal(t, ErrRunnerNotSet, err)
+
+	err = b.Scan()
+	assert.Equal(t, ErrRunnerNotSet, err)
+
 	_, err = b.ExecContext(context.TODO())
 	assert.Equal(t, ErrRunnerNotSet, err)
 }
@@ -0,0 +1,241 @@
+package sqrl
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Dialect is the SQL flavour statements are rendered for. Dialect affects only
+// the clauses that are spelled differently across databases, e.g. RETURNING.
+type Dialect int
+
+const (
+	// Generic is the default dialect. It renders standard SQL with the
+	// extensions commonly supported by PostgreSQL, MySQL and SQLite.
+	Generic Dialect = iota
+	// Postgres is the PostgreSQL dialect.
+	Postgres
+	// MySQL is the MySQL and MariaDB dialect.
+	MySQL
+	// SQLite is the SQLite dialect.
+	SQLite
+	// SQLServer is the Microsoft SQL Server dialect.
+	SQLServer
+	// Oracle is the Oracle Database dialect.
+	Oracle
+)
+
+var dialectNames = [...]string{"Generic", "Postgres", "MySQL", "SQLite", "SQLServer", "Oracle"}
+
+func (d Dialect) String() string {
+	if d < 0 || int(d) >= len(dialectNames) {
+		return "Dialect(?)"
+	}
+	return dialectNames[d]
+}
+
+// checkReturning returns an error if columns are set and the dialect has no
+// RETURNING clause: MySQL has none and Oracle would need output parameters.
+func checkReturning(columns []string, d Dialect) error {
+	if len(columns) > 0 && (d == MySQL || d == Oracle) {
+		return fmt.Errorf("returning clause is not supported by %s dialect", d)
+	}
+	return nil
+}
+
+// appendReturningToSQL writes "RETURNING columns" clause.
+func appendReturningToSQL(b *bytes.Buffer, columns []string) {
+	b.WriteString("RETURNING ")
+	b.WriteString(strings.Join(columns, ", "))
+}
+
+// appendOutputToSQL writes SQL Server "OUTPUT table.column, ..." clause.
+// Columns which already refer to INSERTED or DELETED pseudo tables are
+// written as is.
+func appendOutputToSQL(b *bytes.Buffer, columns []string, table string) {
+	b.WriteString("OUTPUT ")
+	for i, column := range columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		if !strings.Contains(column, ".") {
+			b.WriteString(table + ".")
+		}
+		b.WriteString(column)
+	}
+}
+
+// dialectOptions are the statement settings parts are rendered with.
+type dialectOptions struct {
+	Dialect
+
+	// bindArrays renders slices of Eq and Neq as a single array parameter.
+	bindArrays bool
+}
+
+// dialectSqlizer is implemented by Sqlizers whose SQL depends on the dialect
+// of the statement they are rendered in. Builders pass their options down to
+// such parts, while WriteSQL renders them for Generic dialect.
+type dialectSqlizer interface {
+	writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error)
+}
+
+// writeSQL renders s with options d.
+func writeSQL(b *bytes.Buffer, s Sqlizer, d dialectOptions) ([]interface{}, error) {
+	if ds, ok := s.(dialectSqlizer); ok {
+		return ds.writeDialectSQL(b, d)
+	}
+	return s.WriteSQL(b)
+}
+
+// identQuotes returns the opening and closing identifier quote characters.
+func (d Dialect) identQuotes() (byte, byte) {
+	switch d {
+	case MySQL:
+		return '`', '`'
+	case SQLServer:
+		return '[', ']'
+	default:
+		return '"', '"'
+	}
+}
+
+// QuoteIdent quotes a possibly qualified identifier, e.g. "public.user",
+// with the quote characters of the dialect: "x" for standard SQL, `x` for
+// MySQL and [x] for SQL Server.
+//
+// Parts which are already quoted and a trailing * are kept as is. Anything
+// else than an identifier, e.g. "COUNT(*)" or "a AS b", is returned unchanged,
+// so raw expressions can be passed through the same helper.
+func (d Dialect) QuoteIdent(name string) string {
+	parts, ok := splitIdent(name)
+	if !ok {
+		return name
+	}
+
+	open, close := d.identQuotes()
+
+	var b bytes.Buffer
+	for i, p := range parts {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+
+		if p == "*" || isQuotedIdent(p) {
+			b.WriteString(p)
+			continue
+		}
+
+		b.WriteByte(open)
+		b.WriteString(p)
+		b.WriteByte(close)
+	}
+
+	return b.String()
+}
+
+// QuoteIdents quotes every name with QuoteIdent.
+func (d Dialect) QuoteIdents(names ...string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = d.QuoteIdent(name)
+	}
+	return quoted
+}
+
+// splitIdent splits a dot separated identifier into its parts. ok is false if
+// name is not an identifier.
+func splitIdent(name string) (parts []string, ok bool) {
+	for i := 0; ; {
+		start := i
+
+		switch {
+		case i == len(name):
+			return nil, false
+		case name[i] == '"' || name[i] == '`' || name[i] == '[':
+			closing := name[i]
+			if closing == '[' {
+				closing = ']'
+			}
+
+			i = skipQuotedIdent(name, i+1, closing)
+			if i < 0 {
+				return nil, false
+			}
+		case name[i] == '*':
+			i++
+			if i != len(name) {
+				return nil, false
+			}
+		case name[i] >= '0' && name[i] <= '9' || name[i] == '$':
+			return nil, false
+		default:
+			for i < len(name) && (isIdentByte(name[i]) || name[i] == '$') {
+				i++
+			}
+			if i == start {
+				return nil, false
+			}
+		}
+
+		parts = append(parts, name[start:i])
+
+		if i == len(name) {
+			return parts, true
+		}
+		if name[i] != '.' {
+			return nil, false
+		}
+		i++
+	}
+}
+
+// skipQuotedIdent returns the position after the closing quote of the quoted
+// identifier starting at i or -1 if it is not terminated.
+func skipQuotedIdent(name string, i int, closing byte) int {
+	for ; i < len(name); i++ {
+		if name[i] != closing {
+			continue
+		}
+		if i+1 < len(name) && name[i+1] == closing {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return -1
+}
+
+func isQuotedIdent(p string) bool {
+	switch p[0] {
+	case '"', '`', '[':
+		return true
+	}
+	return false
+}
+
+// ident is an identifier quoted for the dialect of the statement.
+type ident string
+
+// Ident returns an identifier which is quoted with QuoteIdent of the dialect
+// the statement is rendered for. Generic dialect uses standard double quotes.
+//
+// Ex:
+//
+//	.Column(Ident("order"))
+//	.OrderByClause(Ident("user.name"))
+func Ident(name string) Sqlizer {
+	return ident(name)
+}
+
+// WriteSQL implements Sqlizer.
+func (i ident) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return i.writeDialectSQL(b, dialectOptions{})
+}
+
+func (i ident) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	b.WriteString(d.QuoteIdent(string(i)))
+	return nil, nil
+}
@@ -0,0 +1,64 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialectString(t *testing.T) {
+	assert.Equal(t, "Generic", Generic.String())
+	assert.Equal(t, "SQLServer", SQLServer.String())
+	assert.Equal(t, "Dialect(?)", Dialect(100).String())
+}
+
+func TestDialectQuoteIdent(t *testing.T) {
+	cases := []struct {
+		d        Dialect
+		name     string
+		expected string
+	}{
+		{Generic, "order", `"order"`},
+		{Postgres, "public.UserName", `"public"."UserName"`},
+		{MySQL, "user.id", "`user`.`id`"},
+		{SQLServer, "dbo.order", "[dbo].[order]"},
+		{SQLServer, "u.*", "[u].*"},
+		{Postgres, `"Already".quoted`, `"Already"."quoted"`},
+		{Postgres, "*", "*"},
+		{Postgres, "COUNT(*)", "COUNT(*)"},
+		{Postgres, "a AS b", "a AS b"},
+		{Postgres, "a.", "a."},
+		{Postgres, "1", "1"},
+		{Postgres, "", ""},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.d.QuoteIdent(c.name), "%s %q", c.d, c.name)
+	}
+
+	assert.Equal(t, []string{"`a`", "`b`"}, StatementBuilder.Dialect(MySQL).QuoteIdents("a", "b"))
+	assert.Equal(t, "[user]", StatementBuilder.Dialect(SQLServer).QuoteIdent("user"))
+}
+
+func TestIdent(t *testing.T) {
+	sub := Select().Column(Ident("id")).From("t").Where(And{Expr("? = 1", Ident("x"))})
+
+	sql, _, err := Select().
+		Column(Ident("order")).
+		Column(Alias(sub, "s")).
+		From("t").
+		OrderByClause(Ident("t.user")).
+		Dialect(MySQL).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT `order`, (SELECT `id` FROM t WHERE (`x` = 1)) AS s FROM t ORDER BY `t`.`user`", sql)
+
+	sql, _, err = sub.ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "id" FROM t WHERE ("x" = 1)`, sql)
+
+	sql, _, err = Select("a").From("t").Where(Expr("? = ?", Ident("b"), 1)).Dialect(SQLServer).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM t WHERE [b] = ?", sql)
+}
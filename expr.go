@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"reflect"
+	"sort"
 )
 
 type expr struct {
@@ -14,27 +15,38 @@ type expr struct {
 
 // Expr builds value expressions for InsertBuilder and UpdateBuilder.
 //
+// Placeholders are either question marks bound to args by position, or
+// named placeholders like :name bound to Params, DriverParams or sql.Named args.
+//
 // Ex:
 //     .Values(Expr("FROM_UNIXTIME(?)", t))
 func Expr(sql string, args ...interface{}) expr {
 	return expr{sql: sql, args: args}
 }
 
-func (e expr) toSQL(b *bytes.Buffer) ([]interface{}, error) {
-	if !hasSQLWriter(e.args) {
+func (e expr) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return e.writeDialectSQL(b, dialectOptions{})
+}
+
+func (e expr) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	if params, driver, ok := namedArgs(e.args); ok {
+		return writeNamedSQL(b, e.sql, params, driver, d)
+	}
+
+	if !hasSqlizer(e.args) {
 		b.WriteString(e.sql)
 		return e.args, nil
 	}
 
 	args := make([]interface{}, 0, len(e.args))
-	sql, err := replacePlaceholders(e.sql, func(buf *bytes.Buffer, i int) error {
+	sql, err := scanPlaceholders(e.sql, false, d.Dialect, func(buf *bytes.Buffer, i int) error {
 		if i > len(e.args) {
 			buf.WriteRune('?')
 			return nil
 		}
 		switch arg := e.args[i-1].(type) {
-		case sqlWriter:
-			vs, err := arg.toSQL(buf)
+		case Sqlizer:
+			vs, err := writeSQL(buf, arg, d)
 			if err != nil {
 				return err
 			}
@@ -58,33 +70,41 @@ func (e expr) toSQL(b *bytes.Buffer) ([]interface{}, error) {
 	return args, nil
 }
 
-func appendExpressionsToSQL(b *bytes.Buffer, exprs []expr, sep string, args []interface{}) ([]interface{}, error) {
+// appendExpressionsToSQL writes exprs separated by sep. Like any Expr, they
+// may bind Sqlizer args and named parameters.
+func appendExpressionsToSQL(b *bytes.Buffer, exprs []expr, sep string, d dialectOptions, args []interface{}) ([]interface{}, error) {
 	for i, e := range exprs {
 		if i > 0 {
-			_, err := b.WriteString(sep)
-			if err != nil {
-				return nil, err
-			}
+			b.WriteString(sep)
 		}
-		_, err := b.WriteString(e.sql)
+
+		eArgs, err := e.writeDialectSQL(b, d)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(e.args) != 0 {
-			args = append(args, e.args...)
+		if len(eArgs) != 0 {
+			args = append(args, eArgs...)
 		}
 	}
 	return args, nil
 }
 
 // Eq is syntactic sugar for use with Where/Having/Set methods.
+// Slice values are rendered as IN lists, see Array to bind them as a single
+// parameter, and subqueries as "IN (subquery)". Other Sqlizer values, e.g.
+// Expr, are rendered in place of the placeholder.
 // Ex:
 //     .Where(Eq{"id": 1})
+//     .Where(Eq{"id": Select("user_id").From("admins")}) == "id IN (SELECT user_id FROM admins)"
 type Eq map[string]interface{}
 
-func (eq Eq) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
-	return equalityToSQL(eq, b, false)
+func (eq Eq) WriteSQL(b *bytes.Buffer) (args []interface{}, err error) {
+	return equalityToSQL(sortedPairs(eq), b, dialectOptions{}, false)
+}
+
+func (eq Eq) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return equalityToSQL(sortedPairs(eq), b, d, false)
 }
 
 // NotEq is syntactic sugar for use with Where/Having/Set methods.
@@ -92,8 +112,12 @@ func (eq Eq) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
 //     .Where(NotEq{"id": 1}) == "id <> 1"
 type Neq map[string]interface{}
 
-func (neq Neq) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
-	return equalityToSQL(neq, b, true)
+func (neq Neq) WriteSQL(b *bytes.Buffer) (args []interface{}, err error) {
+	return equalityToSQL(sortedPairs(neq), b, dialectOptions{}, true)
+}
+
+func (neq Neq) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return equalityToSQL(sortedPairs(neq), b, d, true)
 }
 
 // Lt is syntactic sugar for use with Where/Having/Set methods.
@@ -101,8 +125,12 @@ func (neq Neq) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
 //     .Where(Lt{"id": 1})
 type Lt map[string]interface{}
 
-func (lt Lt) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
-	return comparisonToSQL(lt, b, false, false)
+func (lt Lt) WriteSQL(b *bytes.Buffer) (args []interface{}, err error) {
+	return comparisonToSQL(sortedPairs(lt), b, dialectOptions{}, false, false)
+}
+
+func (lt Lt) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return comparisonToSQL(sortedPairs(lt), b, d, false, false)
 }
 
 // Lte is syntactic sugar for use with Where/Having/Set methods.
@@ -110,8 +138,12 @@ func (lt Lt) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
 //     .Where(LtOrEq{"id": 1}) == "id <= 1"
 type Lte map[string]interface{}
 
-func (lte Lte) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
-	return comparisonToSQL(lte, b, false, true)
+func (lte Lte) WriteSQL(b *bytes.Buffer) (args []interface{}, err error) {
+	return comparisonToSQL(sortedPairs(lte), b, dialectOptions{}, false, true)
+}
+
+func (lte Lte) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return comparisonToSQL(sortedPairs(lte), b, d, false, true)
 }
 
 // Gt is syntactic sugar for use with Where/Having/Set methods.
@@ -119,8 +151,12 @@ func (lte Lte) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
 //     .Where(Gt{"id": 1}) == "id > 1"
 type Gt map[string]interface{}
 
-func (gt Gt) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
-	return comparisonToSQL(gt, b, true, false)
+func (gt Gt) WriteSQL(b *bytes.Buffer) (args []interface{}, err error) {
+	return comparisonToSQL(sortedPairs(gt), b, dialectOptions{}, true, false)
+}
+
+func (gt Gt) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return comparisonToSQL(sortedPairs(gt), b, d, true, false)
 }
 
 // Gte is syntactic sugar for use with Where/Having/Set methods.
@@ -128,13 +164,98 @@ func (gt Gt) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
 //     .Where(GtOrEq{"id": 1}) == "id >= 1"
 type Gte map[string]interface{}
 
-func (gte Gte) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
-	return comparisonToSQL(gte, b, true, true)
+func (gte Gte) WriteSQL(b *bytes.Buffer) (args []interface{}, err error) {
+	return comparisonToSQL(sortedPairs(gte), b, dialectOptions{}, true, true)
+}
+
+func (gte Gte) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return comparisonToSQL(sortedPairs(gte), b, d, true, true)
+}
+
+// Pair is a column and value pair of the ordered predicates.
+type Pair struct {
+	Column string
+	Value  interface{}
+}
+
+// EqList is an ordered alternative to Eq. Conditions are rendered in the
+// order they are listed.
+// Ex:
+//     .Where(EqList{{"b", 1}, {"a", 2}}) == "b = ? AND a = ?"
+type EqList []Pair
+
+// WriteSQL implements Sqlizer.
+func (eq EqList) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return equalityToSQL(eq, b, dialectOptions{}, false)
+}
+
+func (eq EqList) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return equalityToSQL(eq, b, d, false)
+}
+
+// NeqList is an ordered alternative to Neq.
+type NeqList []Pair
+
+// WriteSQL implements Sqlizer.
+func (neq NeqList) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return equalityToSQL(neq, b, dialectOptions{}, true)
+}
+
+func (neq NeqList) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return equalityToSQL(neq, b, d, true)
+}
+
+// LtList is an ordered alternative to Lt.
+type LtList []Pair
+
+// WriteSQL implements Sqlizer.
+func (lt LtList) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return comparisonToSQL(lt, b, dialectOptions{}, false, false)
+}
+
+func (lt LtList) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return comparisonToSQL(lt, b, d, false, false)
+}
+
+// LteList is an ordered alternative to Lte.
+type LteList []Pair
+
+// WriteSQL implements Sqlizer.
+func (lte LteList) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return comparisonToSQL(lte, b, dialectOptions{}, false, true)
+}
+
+func (lte LteList) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return comparisonToSQL(lte, b, d, false, true)
+}
+
+// GtList is an ordered alternative to Gt.
+type GtList []Pair
+
+// WriteSQL implements Sqlizer.
+func (gt GtList) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return comparisonToSQL(gt, b, dialectOptions{}, true, false)
+}
+
+func (gt GtList) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return comparisonToSQL(gt, b, d, true, false)
+}
+
+// GteList is an ordered alternative to Gte.
+type GteList []Pair
+
+// WriteSQL implements Sqlizer.
+func (gte GteList) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return comparisonToSQL(gte, b, dialectOptions{}, true, true)
+}
+
+func (gte GteList) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return comparisonToSQL(gte, b, d, true, true)
 }
 
 // aliasExpr helps to alias part of SQL query generated with underlying "expr"
 type aliasExpr struct {
-	expr  sqlWriter
+	expr  Sqlizer
 	alias string
 }
 
@@ -142,13 +263,17 @@ type aliasExpr struct {
 // defined as complex expression like IF or CASE
 // Ex:
 //		.Column(Alias(caseStmt, "case_column"))
-func Alias(expr sqlWriter, alias string) aliasExpr {
+func Alias(expr Sqlizer, alias string) aliasExpr {
 	return aliasExpr{expr, alias}
 }
 
-func (e aliasExpr) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
+func (e aliasExpr) WriteSQL(b *bytes.Buffer) (args []interface{}, err error) {
+	return e.writeDialectSQL(b, dialectOptions{})
+}
+
+func (e aliasExpr) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
 	b.WriteByte('(')
-	args, err = e.expr.toSQL(b)
+	args, err = writeSQL(b, e.expr, d)
 	if err != nil {
 		return
 	}
@@ -158,9 +283,9 @@ func (e aliasExpr) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
 	return
 }
 
-type conj []sqlWriter
+type conj []Sqlizer
 
-func (c conj) join(b *bytes.Buffer, sep string) (args []interface{}, err error) {
+func (c conj) join(b *bytes.Buffer, sep string, d dialectOptions) (args []interface{}, err error) {
 	b.WriteByte('(')
 
 	var partArgs []interface{}
@@ -169,7 +294,7 @@ func (c conj) join(b *bytes.Buffer, sep string) (args []interface{}, err error)
 			b.WriteString(sep)
 		}
 
-		partArgs, err = s.toSQL(b)
+		partArgs, err = writeSQL(b, s, d)
 		if err != nil {
 			return
 		}
@@ -186,9 +311,13 @@ func (c conj) join(b *bytes.Buffer, sep string) (args []interface{}, err error)
 //     .Where(And{Expr("a > ?", 15), Expr("b < ?", 20), Expr("c is TRUE")})
 type And conj
 
-// ToSql builds the query into a SQL string and bound args.
-func (a And) toSQL(b *bytes.Buffer) ([]interface{}, error) {
-	return conj(a).join(b, " AND ")
+// WriteSQL implements Sqlizer.
+func (a And) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return conj(a).join(b, " AND ", dialectOptions{})
+}
+
+func (a And) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return conj(a).join(b, " AND ", d)
 }
 
 // Or is syntactic sugar that glues where/having parts with OR clause
@@ -196,12 +325,16 @@ func (a And) toSQL(b *bytes.Buffer) ([]interface{}, error) {
 //     .Where(Or{Expr("a > ?", 15), Expr("b < ?", 20), Expr("c is TRUE")})
 type Or conj
 
-// ToSql builds the query into a SQL string and bound args.
-func (o Or) toSQL(b *bytes.Buffer) ([]interface{}, error) {
-	return conj(o).join(b, " OR ")
+// WriteSQL implements Sqlizer.
+func (o Or) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return conj(o).join(b, " OR ", dialectOptions{})
+}
+
+func (o Or) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return conj(o).join(b, " OR ", d)
 }
 
-func equalityToSQL(m map[string]interface{}, b *bytes.Buffer, useNotOpr bool) (args []interface{}, err error) {
+func equalityToSQL(pairs []Pair, b *bytes.Buffer, d dialectOptions, useNotOpr bool) (args []interface{}, err error) {
 	var (
 		equalOpr = "="
 		inOpr    = "IN"
@@ -214,12 +347,13 @@ func equalityToSQL(m map[string]interface{}, b *bytes.Buffer, useNotOpr bool) (a
 		nullOpr = "IS NOT"
 	}
 
-	first := true
-	for key, val := range m {
-		if !first {
+	for i, p := range pairs {
+		if i > 0 {
 			b.WriteString(" AND ")
 		}
 
+		key, val := p.Column, p.Value
+
 		switch v := val.(type) {
 		case driver.Valuer:
 			if val, err = v.Value(); err != nil {
@@ -227,7 +361,29 @@ func equalityToSQL(m map[string]interface{}, b *bytes.Buffer, useNotOpr bool) (a
 			}
 		}
 
-		if val == nil {
+		if s, ok := val.(Sqlizer); ok {
+			sql, valArgs, subquery, err := operandToSQL(s, d)
+			if err != nil {
+				return nil, err
+			}
+
+			if subquery {
+				b.WriteString(key + " " + inOpr + " (" + sql + ")")
+			} else {
+				b.WriteString(key + " " + equalOpr + " " + sql)
+			}
+
+			args = append(args, valArgs...)
+		} else if arr, ok := val.(arrayValue); ok || d.bindArrays && isList(val) {
+			if ok {
+				val = arr.values
+			}
+
+			args, err = appendArrayToSQL(b, key, val, d.Dialect, useNotOpr, args)
+			if err != nil {
+				return
+			}
+		} else if val == nil {
 			b.WriteString(key + " " + nullOpr + " NULL")
 		} else {
 			valVal := reflect.ValueOf(val)
@@ -248,16 +404,12 @@ func equalityToSQL(m map[string]interface{}, b *bytes.Buffer, useNotOpr bool) (a
 				args = append(args, val)
 			}
 		}
-
-		if first {
-			first = false
-		}
 	}
 
 	return
 }
 
-func comparisonToSQL(m map[string]interface{}, b *bytes.Buffer, opposite, orEq bool) (args []interface{}, err error) {
+func comparisonToSQL(pairs []Pair, b *bytes.Buffer, d dialectOptions, opposite, orEq bool) (args []interface{}, err error) {
 	opr := "<"
 
 	if opposite {
@@ -268,12 +420,13 @@ func comparisonToSQL(m map[string]interface{}, b *bytes.Buffer, opposite, orEq b
 		opr += "="
 	}
 
-	first := true
-	for key, val := range m {
-		if !first {
+	for i, p := range pairs {
+		if i > 0 {
 			b.WriteString(" AND ")
 		}
 
+		key, val := p.Column, p.Value
+
 		switch v := val.(type) {
 		case driver.Valuer:
 			if val, err = v.Value(); err != nil {
@@ -281,6 +434,21 @@ func comparisonToSQL(m map[string]interface{}, b *bytes.Buffer, opposite, orEq b
 			}
 		}
 
+		if s, ok := val.(Sqlizer); ok {
+			sql, valArgs, subquery, err := operandToSQL(s, d)
+			if err != nil {
+				return nil, err
+			}
+
+			if subquery {
+				sql = "(" + sql + ")"
+			}
+			b.WriteString(key + " " + opr + " " + sql)
+
+			args = append(args, valArgs...)
+			continue
+		}
+
 		if val == nil {
 			err = errors.New("cannot use null with less than or greater than operators")
 			return
@@ -295,18 +463,27 @@ func comparisonToSQL(m map[string]interface{}, b *bytes.Buffer, opposite, orEq b
 		b.WriteString(key + " " + opr + " ?")
 
 		args = append(args, val)
-
-		if first {
-			first = false
-		}
 	}
 
 	return
 }
 
-func hasSQLWriter(args []interface{}) bool {
+// sortedPairs returns the map entries ordered by key, so map based predicates
+// always render the same SQL with the same args order.
+func sortedPairs(m map[string]interface{}) []Pair {
+	pairs := make([]Pair, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, Pair{k, v})
+	}
+
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Column < pairs[j].Column })
+
+	return pairs
+}
+
+func hasSqlizer(args []interface{}) bool {
 	for _, arg := range args {
-		_, ok := arg.(sqlWriter)
+		_, ok := arg.(Sqlizer)
 		if ok {
 			return true
 		}
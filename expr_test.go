@@ -12,7 +12,7 @@ func TestEqToSql(t *testing.T) {
 	e := Eq{"id": 1}
 
 	b := &bytes.Buffer{}
-	args, err := e.toSQL(b)
+	args, err := e.WriteSQL(b)
 	assert.NoError(t, err)
 
 	expectedSQL := "id = ?"
@@ -26,7 +26,7 @@ func TestEqInToSql(t *testing.T) {
 	e := Eq{"id": []int{1, 2, 3}}
 
 	b := &bytes.Buffer{}
-	args, err := e.toSQL(b)
+	args, err := e.WriteSQL(b)
 	assert.NoError(t, err)
 
 	expectedSQL := "id IN (?,?,?)"
@@ -40,7 +40,7 @@ func TestNeqToSql(t *testing.T) {
 	e := Neq{"id": 1}
 
 	b := &bytes.Buffer{}
-	args, err := e.toSQL(b)
+	args, err := e.WriteSQL(b)
 	assert.NoError(t, err)
 
 	expectedSQL := "id <> ?"
@@ -54,7 +54,7 @@ func TestNeqInToSql(t *testing.T) {
 	e := Neq{"id": []int{1, 2, 3}}
 
 	b := &bytes.Buffer{}
-	args, err := e.toSQL(b)
+	args, err := e.WriteSQL(b)
 	assert.NoError(t, err)
 
 	expectedSQL := "id NOT IN (?,?,?)"
@@ -65,11 +65,11 @@ func TestNeqInToSql(t *testing.T) {
 }
 
 func TestExprNilToSql(t *testing.T) {
-	var e sqlWriter
+	var e Sqlizer
 	e = Neq{"name": nil}
 
 	b := &bytes.Buffer{}
-	args, err := e.toSQL(b)
+	args, err := e.WriteSQL(b)
 	assert.NoError(t, err)
 	assert.Empty(t, args)
 
@@ -79,7 +79,7 @@ func TestExprNilToSql(t *testing.T) {
 	e = Eq{"name": nil}
 
 	b = &bytes.Buffer{}
-	args, err = e.toSQL(b)
+	args, err = e.WriteSQL(b)
 	assert.NoError(t, err)
 	assert.Empty(t, args)
 
@@ -91,7 +91,7 @@ func TestLtToSql(t *testing.T) {
 	e := Lt{"id": 1}
 
 	b := &bytes.Buffer{}
-	args, err := e.toSQL(b)
+	args, err := e.WriteSQL(b)
 	assert.NoError(t, err)
 
 	expectedSQL := "id < ?"
@@ -105,7 +105,7 @@ func TestLteToSql(t *testing.T) {
 	e := Lte{"id": 1}
 
 	b := &bytes.Buffer{}
-	args, err := e.toSQL(b)
+	args, err := e.WriteSQL(b)
 	assert.NoError(t, err)
 
 	expectedSQL := "id <= ?"
@@ -119,7 +119,7 @@ func TestGtToSql(t *testing.T) {
 	e := Gt{"id": 1}
 
 	b := &bytes.Buffer{}
-	args, err := e.toSQL(b)
+	args, err := e.WriteSQL(b)
 	assert.NoError(t, err)
 
 	expectedSQL := "id > ?"
@@ -133,7 +133,7 @@ func TestGteToSql(t *testing.T) {
 	e := Gte{"id": 1}
 
 	b := &bytes.Buffer{}
-	args, err := e.toSQL(b)
+	args, err := e.WriteSQL(b)
 	assert.NoError(t, err)
 
 	expectedSQL := "id >= ?"
@@ -144,13 +144,13 @@ func TestGteToSql(t *testing.T) {
 }
 
 func TestNullTypeString(t *testing.T) {
-	var e sqlWriter
+	var e Sqlizer
 	var name sql.NullString
 
 	e = Eq{"name": name}
 
 	b := &bytes.Buffer{}
-	args, err := e.toSQL(b)
+	args, err := e.WriteSQL(b)
 	assert.NoError(t, err)
 	assert.Empty(t, args)
 
@@ -160,7 +160,7 @@ func TestNullTypeString(t *testing.T) {
 	e = Eq{"name": name}
 
 	b = &bytes.Buffer{}
-	args, err = e.toSQL(b)
+	args, err = e.WriteSQL(b)
 	assert.NoError(t, err)
 
 	assert.Equal(t, []interface{}{"Name"}, args)
@@ -173,7 +173,7 @@ func TestNullTypeInt64(t *testing.T) {
 	e := Eq{"user_id": userID}
 
 	b := &bytes.Buffer{}
-	args, err := e.toSQL(b)
+	args, err := e.WriteSQL(b)
 	assert.NoError(t, err)
 	assert.Empty(t, args)
 
@@ -183,15 +183,57 @@ func TestNullTypeInt64(t *testing.T) {
 	e = Eq{"user_id": userID}
 
 	b = &bytes.Buffer{}
-	args, err = e.toSQL(b)
+	args, err = e.WriteSQL(b)
 	assert.NoError(t, err)
 	assert.Equal(t, []interface{}{int64(10)}, args)
 	assert.Equal(t, "user_id = ?", b.String())
 }
 
+func TestEqSortedKeys(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		b := &bytes.Buffer{}
+		args, err := Eq{"c": 3, "a": 1, "b": []int{2, 4}}.WriteSQL(b)
+		assert.NoError(t, err)
+		assert.Equal(t, "a = ? AND b IN (?,?) AND c = ?", b.String())
+		assert.Equal(t, []interface{}{1, 2, 4, 3}, args)
+
+		b = &bytes.Buffer{}
+		args, err = Gt{"z": 1, "y": 2}.WriteSQL(b)
+		assert.NoError(t, err)
+		assert.Equal(t, "y > ? AND z > ?", b.String())
+		assert.Equal(t, []interface{}{2, 1}, args)
+	}
+}
+
+func TestOrderedPredicates(t *testing.T) {
+	tests := []struct {
+		pred Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{EqList{{"c", 1}, {"a", nil}, {"b", []int{2, 3}}}, "c = ? AND a IS NULL AND b IN (?,?)", []interface{}{1, 2, 3}},
+		{NeqList{{"c", 1}, {"a", nil}}, "c <> ? AND a IS NOT NULL", []interface{}{1}},
+		{LtList{{"b", 1}, {"a", 2}}, "b < ? AND a < ?", []interface{}{1, 2}},
+		{LteList{{"b", 1}}, "b <= ?", []interface{}{1}},
+		{GtList{{"b", 1}}, "b > ?", []interface{}{1}},
+		{GteList{{"b", 1}, {"a", 2}}, "b >= ? AND a >= ?", []interface{}{1, 2}},
+	}
+
+	for _, test := range tests {
+		b := &bytes.Buffer{}
+		args, err := test.pred.WriteSQL(b)
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, b.String())
+		assert.Equal(t, test.args, args)
+	}
+
+	_, err := LtList{{"a", nil}}.WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+}
+
 type dummySqlizer int
 
-func (d dummySqlizer) toSQL(b *bytes.Buffer) ([]interface{}, error) {
+func (d dummySqlizer) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
 	b.WriteString("DUMMY(?, ?)")
 	return []interface{}{int(d), int(d)}, nil
 }
@@ -200,7 +242,7 @@ func TestExprSqlizer(t *testing.T) {
 	e := Expr("EXISTS(?)", dummySqlizer(42))
 
 	b := &bytes.Buffer{}
-	args, err := e.toSQL(b)
+	args, err := e.WriteSQL(b)
 	assert.NoError(t, err)
 
 	assert.Equal(t, "EXISTS(DUMMY(?, ?))", b.String())
@@ -213,7 +255,7 @@ func BenchmarkEqToSql(b *testing.B) {
 		eq := Eq{"test": nil}
 
 		for n := 0; n < b.N; n++ {
-			eq.toSQL(&bytes.Buffer{})
+			eq.WriteSQL(&bytes.Buffer{})
 		}
 	})
 
@@ -221,7 +263,7 @@ func BenchmarkEqToSql(b *testing.B) {
 		eq := Eq{"test": 5}
 
 		for n := 0; n < b.N; n++ {
-			eq.toSQL(&bytes.Buffer{})
+			eq.WriteSQL(&bytes.Buffer{})
 		}
 	})
 
@@ -229,7 +271,7 @@ func BenchmarkEqToSql(b *testing.B) {
 		eq := Eq{"test": []int{1, 2, 3, 4, 5}}
 
 		for n := 0; n < b.N; n++ {
-			eq.toSQL(&bytes.Buffer{})
+			eq.WriteSQL(&bytes.Buffer{})
 		}
 	})
 }
@@ -238,6 +280,6 @@ func BenchmarkLtToSql(b *testing.B) {
 	lt := Lt{"test": 5}
 
 	for n := 0; n < b.N; n++ {
-		lt.toSQL(&bytes.Buffer{})
+		lt.WriteSQL(&bytes.Buffer{})
 	}
 }
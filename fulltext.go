@@ -0,0 +1,306 @@
+package sqrl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TextSearchMode tells how the query of FullTextSearch is interpreted.
+type TextSearchMode int
+
+const (
+	// PlainText searches the words of the query. The semantics differ per
+	// dialect: plainto_tsquery of Postgres matches rows with all the words,
+	// while natural language mode of MySQL and FREETEXT of SQL Server match
+	// rows with any of them, ranked by relevance.
+	PlainText TextSearchMode = iota
+	// PhraseText matches the words of the query in the given order. It is
+	// rendered with phraseto_tsquery for Postgres and as a quoted phrase in
+	// boolean mode for MySQL.
+	PhraseText
+	// WebSearchText accepts the syntax of web search engines: all the words
+	// are required except alternatives joined with "or", quoted phrases and
+	// "-" for exclusion. It is rendered with websearch_to_tsquery for
+	// Postgres, while for MySQL the query is translated to boolean mode, e.g.
+	// `a "b c" or d -e` becomes `+a +("b c" d) -e`.
+	WebSearchText
+	// BooleanText passes the query in the native syntax of the database,
+	// i.e. to_tsquery for Postgres and boolean mode for MySQL.
+	BooleanText
+)
+
+// FullTextSearch is a full-text search predicate:
+//
+//	Generic, Postgres: to_tsvector(a) @@ plainto_tsquery(?)
+//	MySQL:             MATCH(a, b) AGAINST(? IN NATURAL LANGUAGE MODE)
+//	SQLServer:         FREETEXT((a, b), ?)
+//
+// Multiple columns are concatenated for Postgres. SQLite and Oracle dialects
+// return an error.
+type FullTextSearch struct {
+	columns []string
+	query   string
+	config  string
+	mode    TextSearchMode
+}
+
+// FullText returns a full-text search of query in columns. The columns need
+// a full-text index in MySQL and SQL Server.
+// Ex:
+//
+//	.Where(FullText(q, "title", "body").Config("english"))
+func FullText(query string, columns ...string) FullTextSearch {
+	return FullTextSearch{columns: columns, query: query}
+}
+
+// Config sets the text search configuration, e.g. "english", which is the
+// language of the text for Postgres and SQL Server. MySQL ignores it, since
+// its parser is chosen by the full-text index.
+func (s FullTextSearch) Config(config string) FullTextSearch {
+	s.config = config
+	return s
+}
+
+// Mode sets how the query is interpreted, PlainText by default.
+func (s FullTextSearch) Mode(mode TextSearchMode) FullTextSearch {
+	s.mode = mode
+	return s
+}
+
+// Rank returns relevance of the rows matched by the search, to be used as a
+// column or in OrderByClause:
+//
+//	Generic, Postgres: ts_rank(to_tsvector(a), plainto_tsquery(?))
+//	MySQL:             MATCH(a) AGAINST(? IN NATURAL LANGUAGE MODE)
+//
+// Other dialects return an error.
+// Ex:
+//
+//	.Column(Alias(s.Rank(), "score")).Where(s).OrderByClause("? DESC", s.Rank())
+func (s FullTextSearch) Rank() Sqlizer {
+	return fullTextRank{s}
+}
+
+// WriteSQL implements Sqlizer.
+func (s FullTextSearch) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return s.writeDialectSQL(b, dialectOptions{})
+}
+
+func (s FullTextSearch) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+
+	switch d.Dialect {
+	case Generic, Postgres:
+		s.writeVector(b)
+		b.WriteString(" @@ ")
+		s.writeTSQuery(b)
+	case MySQL:
+		s.writeMatch(b)
+	case SQLServer:
+		if s.mode == WebSearchText {
+			return nil, fmt.Errorf("web search mode is not supported by %s dialect", d.Dialect)
+		}
+
+		if s.mode == PlainText {
+			b.WriteString("FREETEXT(")
+		} else {
+			b.WriteString("CONTAINS(")
+		}
+		if len(s.columns) == 1 {
+			b.WriteString(s.columns[0])
+		} else {
+			b.WriteString("(" + strings.Join(s.columns, ", ") + ")")
+		}
+		b.WriteString(", ?")
+		if s.config != "" {
+			b.WriteString(", LANGUAGE " + sqlStringLiteral(s.config, d.Dialect))
+		}
+		b.WriteByte(')')
+	default:
+		return nil, fmt.Errorf("full-text search is not supported by %s dialect", d.Dialect)
+	}
+
+	return []interface{}{s.arg(d.Dialect)}, nil
+}
+
+func (s FullTextSearch) check() error {
+	if len(s.columns) == 0 {
+		return errors.New("full-text search must have at least one column")
+	}
+	if s.mode < PlainText || s.mode > BooleanText {
+		return fmt.Errorf("unknown text search mode %d", s.mode)
+	}
+	return nil
+}
+
+// arg returns the query bound to the placeholder. A phrase is quoted for
+// MySQL and SQL Server, which search it in boolean mode and with CONTAINS.
+func (s FullTextSearch) arg(d Dialect) string {
+	switch {
+	case s.mode == WebSearchText && d == MySQL:
+		return mysqlWebSearch(s.query)
+	case s.mode != PhraseText || d != MySQL && d != SQLServer:
+		return s.query
+	}
+	return `"` + strings.Replace(s.query, `"`, " ", -1) + `"`
+}
+
+// mysqlWebSearch translates web search syntax to MySQL boolean mode: words
+// and phrases are required, alternatives joined with "or" are grouped and
+// excluded terms are kept. Boolean mode operators in words are dropped.
+func mysqlWebSearch(query string) string {
+	var (
+		groups  [][]string
+		terms   []string // excluded terms
+		exclude bool
+		or      bool
+	)
+
+	for p := 0; p < len(query); {
+		c := query[p]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			p++
+			continue
+		case c == '-':
+			exclude = true
+			p++
+			continue
+		}
+
+		var term string
+		if c == '"' {
+			end := strings.IndexByte(query[p+1:], '"')
+			if end == -1 {
+				end = len(query) - p - 1
+			}
+			if phrase := strings.Join(strings.Fields(query[p+1:p+1+end]), " "); phrase != "" {
+				term = `"` + phrase + `"`
+			}
+			p += end + 2
+		} else {
+			end := strings.IndexAny(query[p:], " \t\n\r\"")
+			if end == -1 {
+				end = len(query) - p
+			}
+			word := query[p : p+end]
+			p += end
+
+			if strings.EqualFold(word, "or") && !exclude && len(groups) > 0 {
+				or = true
+				continue
+			}
+			term = strings.Map(func(r rune) rune {
+				if strings.ContainsRune("+-<>()~*@", r) {
+					return -1
+				}
+				return r
+			}, word)
+		}
+
+		switch {
+		case term == "":
+		case exclude:
+			terms = append(terms, "-"+term)
+		case or:
+			groups[len(groups)-1] = append(groups[len(groups)-1], term)
+		default:
+			groups = append(groups, []string{term})
+		}
+		exclude, or = false, false
+	}
+
+	words := make([]string, 0, len(groups)+len(terms))
+	for _, group := range groups {
+		if len(group) == 1 {
+			words = append(words, "+"+group[0])
+		} else {
+			words = append(words, "+("+strings.Join(group, " ")+")")
+		}
+	}
+
+	return strings.Join(append(words, terms...), " ")
+}
+
+// writeVector writes Postgres "to_tsvector(config, a || ' ' || b)".
+func (s FullTextSearch) writeVector(b *bytes.Buffer) {
+	b.WriteString("to_tsvector(")
+	if s.config != "" {
+		b.WriteString(sqlStringLiteral(s.config, Postgres) + ", ")
+	}
+
+	if len(s.columns) == 1 {
+		b.WriteString(s.columns[0])
+	} else {
+		// a NULL column would make the whole document NULL
+		for i, column := range s.columns {
+			if i > 0 {
+				b.WriteString(" || ' ' || ")
+			}
+			b.WriteString("coalesce(" + column + ", '')")
+		}
+	}
+
+	b.WriteByte(')')
+}
+
+var tsQueryFuncs = [...]string{
+	PlainText:     "plainto_tsquery(",
+	PhraseText:    "phraseto_tsquery(",
+	WebSearchText: "websearch_to_tsquery(",
+	BooleanText:   "to_tsquery(",
+}
+
+// writeTSQuery writes Postgres "plainto_tsquery(config, ?)".
+func (s FullTextSearch) writeTSQuery(b *bytes.Buffer) {
+	b.WriteString(tsQueryFuncs[s.mode])
+	if s.config != "" {
+		b.WriteString(sqlStringLiteral(s.config, Postgres) + ", ")
+	}
+	b.WriteString("?)")
+}
+
+// writeMatch writes MySQL "MATCH(a, b) AGAINST(? IN mode)".
+func (s FullTextSearch) writeMatch(b *bytes.Buffer) {
+	b.WriteString("MATCH(" + strings.Join(s.columns, ", ") + ") AGAINST(?")
+	if s.mode == PlainText {
+		b.WriteString(" IN NATURAL LANGUAGE MODE)")
+	} else {
+		b.WriteString(" IN BOOLEAN MODE)")
+	}
+}
+
+type fullTextRank struct {
+	search FullTextSearch
+}
+
+// WriteSQL implements Sqlizer.
+func (r fullTextRank) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return r.writeDialectSQL(b, dialectOptions{})
+}
+
+func (r fullTextRank) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	s := r.search
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+
+	switch d.Dialect {
+	case Generic, Postgres:
+		b.WriteString("ts_rank(")
+		s.writeVector(b)
+		b.WriteString(", ")
+		s.writeTSQuery(b)
+		b.WriteByte(')')
+	case MySQL:
+		s.writeMatch(b)
+	default:
+		return nil, fmt.Errorf("full-text search rank is not supported by %s dialect", d.Dialect)
+	}
+
+	return []interface{}{s.arg(d.Dialect)}, nil
+}
@@ -0,0 +1,93 @@
+package sqrl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFullText(t *testing.T) {
+	cases := []struct {
+		d        Dialect
+		search   FullTextSearch
+		expected string
+		arg      string
+	}{
+		{Postgres, FullText("go sql", "body"), "to_tsvector(body) @@ plainto_tsquery(?)", "go sql"},
+		{Postgres, FullText("go sql", "title", "body").Config("english").Mode(PhraseText), "to_tsvector('english', coalesce(title, '') || ' ' || coalesce(body, '')) @@ phraseto_tsquery('english', ?)", "go sql"},
+		{Generic, FullText("go -java", "body").Mode(WebSearchText), "to_tsvector(body) @@ websearch_to_tsquery(?)", "go -java"},
+		{Postgres, FullText("go & sql", "body").Mode(BooleanText), "to_tsvector(body) @@ to_tsquery(?)", "go & sql"},
+		{MySQL, FullText("go sql", "title", "body").Config("english"), "MATCH(title, body) AGAINST(? IN NATURAL LANGUAGE MODE)", "go sql"},
+		{MySQL, FullText("+go -java", "body").Mode(BooleanText), "MATCH(body) AGAINST(? IN BOOLEAN MODE)", "+go -java"},
+		{MySQL, FullText(`go "sql`, "body").Mode(PhraseText), "MATCH(body) AGAINST(? IN BOOLEAN MODE)", `"go  sql"`},
+		{SQLServer, FullText("go sql", "title", "body").Config("English"), "FREETEXT((title, body), ?, LANGUAGE 'English')", "go sql"},
+		{SQLServer, FullText("go sql", "body").Mode(PhraseText), "CONTAINS(body, ?)", `"go sql"`},
+	}
+
+	for _, c := range cases {
+		sql, args, err := Select("id").From("t").Where(c.search).Dialect(c.d).ToSQL()
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT id FROM t WHERE "+c.expected, sql)
+		assert.Equal(t, []interface{}{c.arg}, args)
+	}
+
+	for _, s := range []Sqlizer{
+		FullText("go"),
+		FullText("go", "body").Mode(TextSearchMode(9)),
+	} {
+		_, err := s.WriteSQL(&bytes.Buffer{})
+		assert.Error(t, err)
+	}
+
+	for _, d := range []Dialect{SQLite, Oracle} {
+		_, _, err := Select("id").From("t").Where(FullText("go", "body")).Dialect(d).ToSQL()
+		assert.Error(t, err)
+	}
+
+	_, _, err := Select("id").From("t").Where(FullText("go", "body").Mode(WebSearchText)).Dialect(SQLServer).ToSQL()
+	assert.Error(t, err)
+}
+
+func TestMySQLWebSearch(t *testing.T) {
+	cases := map[string]string{
+		`go sql`:                 `+go +sql`,
+		`a "b  c" or d -e`:       `+a +("b c" d) -e`,
+		`or x OR y or z`:         `+or +(x y z)`,
+		`c++ -"old api" (beta)*`: `+c +beta -"old api"`,
+		`"unclosed phrase`:       `+"unclosed phrase"`,
+		`  `:                     ``,
+	}
+
+	for query, expected := range cases {
+		assert.Equal(t, expected, mysqlWebSearch(query), query)
+	}
+
+	_, args, err := Select("id").From("t").Where(FullText("go or rust -java", "body").Mode(WebSearchText)).Dialect(MySQL).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"+(go rust) -java"}, args)
+}
+
+func TestFullTextRank(t *testing.T) {
+	s := FullText("go", "body").Config("english")
+	q := func(d Dialect) *SelectBuilder {
+		return Select("id").
+			Column(Alias(s.Rank(), "score")).
+			From("t").
+			Where(s).
+			OrderByClause("? DESC", s.Rank()).
+			Dialect(d)
+	}
+
+	sql, args, err := q(Postgres).PlaceholderFormat(Dollar).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id, (ts_rank(to_tsvector('english', body), plainto_tsquery('english', $1))) AS score FROM t WHERE to_tsvector('english', body) @@ plainto_tsquery('english', $2) ORDER BY ts_rank(to_tsvector('english', body), plainto_tsquery('english', $3)) DESC", sql)
+	assert.Equal(t, []interface{}{"go", "go", "go"}, args)
+
+	sql, _, err = q(MySQL).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id, (MATCH(body) AGAINST(? IN NATURAL LANGUAGE MODE)) AS score FROM t WHERE MATCH(body) AGAINST(? IN NATURAL LANGUAGE MODE) ORDER BY MATCH(body) AGAINST(? IN NATURAL LANGUAGE MODE) DESC", sql)
+
+	_, _, err = q(SQLServer).ToSQL()
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
 )
 
@@ -12,12 +13,18 @@ import (
 type InsertBuilder struct {
 	StatementBuilderType
 
-	prefixes []expr
-	options  []string
-	into     string
-	columns  []string
-	values   [][]interface{}
-	suffixes []expr
+	ctes      []cte
+	prefixes  []expr
+	options   []string
+	into      string
+	columns   []string
+	values    [][]interface{}
+	selectB   *SelectBuilder
+	conflict  *OnConflictBuilder
+	rowAlias  string
+	dupKey    []setClause
+	returning []string
+	suffixes  []expr
 }
 
 // NewInsertBuilder creates new instance of InsertBuilder
@@ -86,24 +93,95 @@ func (b *InsertBuilder) PlaceholderFormat(f PlaceholderFormat) *InsertBuilder {
 	return b
 }
 
-// ToSql builds the query into a SQL string and bound args.
+// Dialect sets Dialect (e.g. Postgres or SQLServer) for the query.
+func (b *InsertBuilder) Dialect(d Dialect) *InsertBuilder {
+	b.dialect = d
+	return b
+}
+
+// BindArrays sets whether slice values of Eq and Neq are bound as a single
+// array parameter, see Array.
+func (b *InsertBuilder) BindArrays(enabled bool) *InsertBuilder {
+	b.bindArrays = enabled
+	return b
+}
+
+// ToSQL builds the query into a SQL string and bound args.
 func (b *InsertBuilder) ToSQL() (sqlStr string, args []interface{}, err error) {
+	sql := &bytes.Buffer{}
+	args, err = b.WriteSQL(sql)
+	if err != nil {
+		return
+	}
+
+	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
+	args = namedArgsLast(args)
+	return
+}
+
+// WriteSQL implements Sqlizer, so the statement can be used within other
+// queries, e.g. as a data-modifying WITH query.
+func (b *InsertBuilder) WriteSQL(sql *bytes.Buffer) (args []interface{}, err error) {
+	return b.writeDialectSQL(sql, dialectOptions{})
+}
+
+func (b *InsertBuilder) writeDialectSQL(sql *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	d = b.renderOptions(d)
+
 	if len(b.into) == 0 {
 		err = errors.New("insert statements must specify a table")
 		return
 	}
-	if len(b.values) == 0 {
-		err = errors.New("insert statements must have at least one set of values")
+	if len(b.values) == 0 && b.selectB == nil {
+		err = errors.New("insert statements must have at least one set of values or select clause")
+		return
+	}
+	if len(b.values) > 0 && b.selectB != nil {
+		err = errors.New("insert statements cannot have both values and select clause")
+		return
+	}
+	if len(b.rowAlias) > 0 && b.selectB != nil {
+		err = errors.New("insert statements cannot have both row alias and select clause")
+		return
+	}
+	if err = checkReturning(b.returning, d.Dialect); err != nil {
+		return
+	}
+	if b.conflict != nil && len(b.dupKey) > 0 {
+		err = errors.New("insert statements cannot have both ON CONFLICT and ON DUPLICATE KEY UPDATE clauses")
+		return
+	}
+	if b.conflict != nil && d.Dialect != Generic && d.Dialect != Postgres && d.Dialect != SQLite {
+		err = fmt.Errorf("ON CONFLICT clause is not supported by %s dialect", d.Dialect)
+		return
+	}
+	if (len(b.dupKey) > 0 || len(b.rowAlias) > 0) && d.Dialect != Generic && d.Dialect != MySQL {
+		err = fmt.Errorf("ON DUPLICATE KEY UPDATE clause is not supported by %s dialect", d.Dialect)
 		return
 	}
 
-	sql := &bytes.Buffer{}
+	// MySQL and Oracle accept WITH clause only in the select of INSERT
+	withInSelect := d.Dialect == MySQL || d.Dialect == Oracle
+	if len(b.ctes) > 0 && withInSelect && b.selectB == nil {
+		err = fmt.Errorf("%s dialect supports WITH clause in INSERT statements only with select clause", d.Dialect)
+		return
+	}
 
 	if len(b.prefixes) > 0 {
-		args, _ = appendExpressionsToSQL(sql, b.prefixes, " ", args)
+		args, err = appendExpressionsToSQL(sql, b.prefixes, " ", d, args)
+		if err != nil {
+			return
+		}
 		sql.WriteString(" ")
 	}
 
+	if len(b.ctes) > 0 && !withInSelect {
+		args, err = appendCTEsToSQL(sql, b.ctes, d, args)
+		if err != nil {
+			return
+		}
+	}
+
 	sql.WriteString("INSERT ")
 
 	if len(b.options) > 0 {
@@ -121,50 +199,80 @@ func (b *InsertBuilder) ToSQL() (sqlStr string, args []interface{}, err error) {
 		sql.WriteString(") ")
 	}
 
-	sql.WriteString("VALUES ")
+	if len(b.returning) > 0 && d.Dialect == SQLServer {
+		appendOutputToSQL(sql, b.returning, "INSERTED")
+		sql.WriteString(" ")
+	}
 
-	for r, row := range b.values {
-		if r > 0 {
-			sql.WriteString(",")
+	if b.selectB != nil {
+		if len(b.ctes) > 0 && withInSelect {
+			args, err = appendCTEsToSQL(sql, b.ctes, d, args)
+			if err != nil {
+				return
+			}
 		}
+		args, err = appendToSQL([]Sqlizer{b.selectB}, sql, "", d, args)
+	} else {
+		args, err = appendValuesToSQL(sql, b.values, d, false, args)
+	}
+	if err != nil {
+		return
+	}
 
-		sql.WriteString("(")
+	if len(b.rowAlias) > 0 {
+		sql.WriteString(" AS ")
+		sql.WriteString(b.rowAlias)
+	}
 
-		for v, val := range row {
-			if v > 0 {
-				sql.WriteString(",")
-			}
+	if len(b.dupKey) > 0 {
+		sql.WriteString(" ON DUPLICATE KEY UPDATE ")
+		args, err = appendSetClausesToSQL(sql, b.duplicateKeyClauses(), d, args)
+		if err != nil {
+			return
+		}
+	}
 
-			switch typedVal := val.(type) {
-			case sqlWriter:
-				var valArgs []interface{}
-
-				valArgs, err = typedVal.toSQL(sql)
-				if err != nil {
-					return
-				}
-
-				if len(valArgs) > 0 {
-					args = append(args, valArgs...)
-				}
-			default:
-				sql.WriteString("?")
-				args = append(args, val)
-			}
+	if b.conflict != nil {
+		sql.WriteString(" ")
+		args, err = appendToSQL([]Sqlizer{b.conflict}, sql, "", d, args)
+		if err != nil {
+			return
 		}
+	}
 
-		sql.WriteString(")")
+	if len(b.returning) > 0 && d.Dialect != SQLServer {
+		sql.WriteString(" ")
+		appendReturningToSQL(sql, b.returning)
 	}
 
 	if len(b.suffixes) > 0 {
 		sql.WriteString(" ")
-		args, _ = appendExpressionsToSQL(sql, b.suffixes, " ", args)
+		args, err = appendExpressionsToSQL(sql, b.suffixes, " ", d, args)
+		if err != nil {
+			return
+		}
 	}
 
-	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
 	return
 }
 
+// With adds a named query to WITH clause of the query. The query can be
+// any Sqlizer, e.g. SelectBuilder or a data-modifying statement like
+// InsertBuilder. Its args precede the args of the main query. MySQL and
+// Oracle only accept WITH clause before the select of "INSERT ... SELECT",
+// where it is rendered for them.
+func (b *InsertBuilder) With(name string, query Sqlizer) *InsertBuilder {
+	b.ctes = append(b.ctes, cte{name: name, query: query})
+	return b
+}
+
+// WithRecursive adds a recursive named query with optional column names to
+// WITH clause of the query, usually a CompoundBuilder.
+func (b *InsertBuilder) WithRecursive(name string, columns []string, query Sqlizer) *InsertBuilder {
+	b.ctes = append(b.ctes, cte{name: name, columns: columns, recursive: true, query: query})
+	return b
+}
+
 // Prefix adds an expression to the beginning of the query
 func (b *InsertBuilder) Prefix(sql string, args ...interface{}) *InsertBuilder {
 	b.prefixes = append(b.prefixes, Expr(sql, args...))
@@ -195,6 +303,94 @@ func (b *InsertBuilder) Values(values ...interface{}) *InsertBuilder {
 	return b
 }
 
+// Select sets a SELECT statement as the source of inserted rows, rendering
+// "INSERT INTO table (columns) SELECT ...". It can't be combined with Values.
+func (b *InsertBuilder) Select(sb *SelectBuilder) *InsertBuilder {
+	b.selectB = sb
+	return b
+}
+
+// OnConflict sets PostgreSQL "ON CONFLICT" clause of the query, which SQLite
+// also supports. Other dialects return an error.
+//
+// Ex:
+//
+//	Insert("users").Columns("id", "name").Values(1, "moe").
+//		OnConflict(OnConflict("id").SetExcluded("name"))
+func (b *InsertBuilder) OnConflict(c *OnConflictBuilder) *InsertBuilder {
+	b.conflict = c
+	return b
+}
+
+// RowAlias sets MySQL 8.0.19+ row alias of inserted values: "VALUES (...) AS alias".
+// OnDuplicateKeyUpdateColumns refers to the inserted values via the alias
+// instead of the deprecated VALUES() function.
+func (b *InsertBuilder) RowAlias(alias string) *InsertBuilder {
+	b.rowAlias = alias
+	return b
+}
+
+// OnDuplicateKeyUpdate adds a clause to MySQL "ON DUPLICATE KEY UPDATE" part
+// of the query. Values are handled the same way as in UpdateBuilder.Set.
+// Dialects other than MySQL return an error.
+func (b *InsertBuilder) OnDuplicateKeyUpdate(column string, value interface{}) *InsertBuilder {
+	b.dupKey = addSetClause(b.dupKey, column, value)
+	return b
+}
+
+// OnDuplicateKeyUpdateMap is a convenience method which calls .OnDuplicateKeyUpdate
+// for each key/value pair in clauses. The pairs are added in the order of sorted keys.
+func (b *InsertBuilder) OnDuplicateKeyUpdateMap(clauses map[string]interface{}) *InsertBuilder {
+	for _, p := range sortedPairs(clauses) {
+		b.OnDuplicateKeyUpdate(p.Column, p.Value)
+	}
+
+	return b
+}
+
+// OnDuplicateKeyUpdateColumns sets each column to the value proposed for
+// insertion: "column = VALUES(column)", or "column = alias.column" when
+// RowAlias is set.
+func (b *InsertBuilder) OnDuplicateKeyUpdateColumns(columns ...string) *InsertBuilder {
+	for _, column := range columns {
+		b.OnDuplicateKeyUpdate(column, insertedValue(column))
+	}
+
+	return b
+}
+
+// insertedValue is a placeholder for the value proposed for insertion, it is
+// resolved when the query is built since it depends on the row alias.
+type insertedValue string
+
+func (b *InsertBuilder) duplicateKeyClauses() []setClause {
+	clauses := make([]setClause, len(b.dupKey))
+	for i, c := range b.dupKey {
+		clauses[i] = c
+
+		column, ok := c.value.(insertedValue)
+		if !ok {
+			continue
+		}
+
+		if len(b.rowAlias) > 0 {
+			clauses[i].value = Expr(b.rowAlias + "." + string(column))
+		} else {
+			clauses[i].value = Expr("VALUES(" + string(column) + ")")
+		}
+	}
+
+	return clauses
+}
+
+// Returning adds columns to RETURNING clause of the query. The clause is
+// rendered as "OUTPUT INSERTED.column" for SQLServer dialect.
+// MySQL and Oracle dialects return an error.
+func (b *InsertBuilder) Returning(columns ...string) *InsertBuilder {
+	b.returning = append(b.returning, columns...)
+	return b
+}
+
 // Suffix adds an expression to the end of the query
 func (b *InsertBuilder) Suffix(sql string, args ...interface{}) *InsertBuilder {
 	b.suffixes = append(b.suffixes, Expr(sql, args...))
@@ -202,16 +398,15 @@ func (b *InsertBuilder) Suffix(sql string, args ...interface{}) *InsertBuilder {
 }
 
 // SetMap set columns and values for insert builder from a map of column name and value
-// note that it will reset all previous columns and values was set if any
+// note that it will reset all previous columns and values was set if any.
+// Columns are sorted by name.
 func (b *InsertBuilder) SetMap(clauses map[string]interface{}) *InsertBuilder {
 	b.columns = make([]string, len(clauses))
 	vals := make([]interface{}, len(clauses))
 
-	i := 0
-	for col, val := range clauses {
-		b.columns[i] = col
-		vals[i] = val
-		i++
+	for i, p := range sortedPairs(clauses) {
+		b.columns[i] = p.Column
+		vals[i] = p.Value
 	}
 
 	b.values = [][]interface{}{vals}
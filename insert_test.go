@@ -85,6 +85,101 @@ func TestInsertBuilderSetMap(t *testing.T) {
 	assert.Equal(t, expectedArgs, args)
 }
 
+func TestInsertBuilderSetMapOrder(t *testing.T) {
+	b := Insert("table").SetMap(map[string]interface{}{"c": 3, "a": 1, "b": 2})
+
+	sql, args, err := b.ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO table (a,b,c) VALUES (?,?,?)", sql)
+	assert.Equal(t, []interface{}{1, 2, 3}, args)
+}
+
+func TestInsertBuilderSelect(t *testing.T) {
+	sb := Select("b", "c").From("d").Where(Eq{"e": 1})
+
+	sql, args, err := Insert("a").
+		Prefix("WITH p AS (SELECT ?)", 0).
+		Columns("b", "c").
+		Select(sb).
+		Suffix("RETURNING ?", 2).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "WITH p AS (SELECT $1) INSERT INTO a (b,c) SELECT b, c FROM d WHERE e = $2 RETURNING $3", sql)
+	assert.Equal(t, []interface{}{0, 1, 2}, args)
+
+	_, _, err = Insert("a").Values(1).Select(sb).ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = Insert("a").Select(Select()).ToSQL()
+	assert.Error(t, err)
+}
+
+func TestInsertBuilderReturning(t *testing.T) {
+	b := Insert("a").Columns("b").Values(1).Returning("id", "b")
+
+	sql, args, err := b.ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (b) VALUES (?) RETURNING id, b", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, _, err = b.Dialect(SQLServer).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (b) OUTPUT INSERTED.id, INSERTED.b VALUES (?)", sql)
+
+	sql, _, err = Insert("a").Values(1).OnConflict(OnConflict()).Returning("*").Dialect(Postgres).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a VALUES (?) ON CONFLICT DO NOTHING RETURNING *", sql)
+
+	_, _, err = b.Dialect(Oracle).ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = b.Dialect(MySQL).ToSQL()
+	assert.Error(t, err)
+}
+
+func TestInsertBuilderOnDuplicateKeyUpdate(t *testing.T) {
+	sql, args, err := Insert("a").
+		Columns("id", "b", "c").
+		Values(1, 2, 3).
+		OnDuplicateKeyUpdateColumns("b").
+		OnDuplicateKeyUpdate("c", Expr("c + ?", 4)).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (id,b,c) VALUES (?,?,?) ON DUPLICATE KEY UPDATE b = VALUES(b), c = c + ?", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, args)
+
+	sql, args, err = Insert("a").
+		Columns("id", "b", "c").
+		Values(1, 2, 3).
+		RowAlias("new").
+		OnDuplicateKeyUpdateMap(map[string]interface{}{"c": 4, "b": Expr("new.b + a.b")}).
+		OnDuplicateKeyUpdateColumns("c").
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (id,b,c) VALUES (?,?,?) AS new ON DUPLICATE KEY UPDATE b = new.b + a.b, c = new.c", sql)
+	assert.Equal(t, []interface{}{1, 2, 3}, args)
+
+	_, _, err = Insert("a").RowAlias("new").Select(Select("1")).ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = Insert("a").Values(1).OnConflict(OnConflict()).OnDuplicateKeyUpdateColumns("a").ToSQL()
+	assert.Error(t, err)
+
+	sql, _, err = Insert("a").Columns("a").Values(1).OnDuplicateKeyUpdateColumns("a").Dialect(MySQL).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (a) VALUES (?) ON DUPLICATE KEY UPDATE a = VALUES(a)", sql)
+
+	_, _, err = Insert("a").Columns("a").Values(1).OnDuplicateKeyUpdateColumns("a").Dialect(Postgres).ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = Insert("a").Values(1).RowAlias("new").Dialect(SQLite).ToSQL()
+	assert.Error(t, err)
+}
+
 func BenchmarkInsertSetMap(b *testing.B) {
 	m := map[string]interface{}{
 		"test":   3,
@@ -0,0 +1,34 @@
+package sqrl
+
+import "bytes"
+
+// joinPart is a structured join clause: "JOIN table ON condition".
+type joinPart struct {
+	kind  string
+	table Sqlizer
+	on    Sqlizer
+}
+
+func newJoinPart(kind string, table interface{}, on interface{}, args ...interface{}) Sqlizer {
+	return joinPart{kind: kind, table: newPart(table), on: newWherePart(on, args...)}
+}
+
+func (j joinPart) WriteSQL(b *bytes.Buffer) (args []interface{}, err error) {
+	return j.writeDialectSQL(b, dialectOptions{})
+}
+
+func (j joinPart) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	b.WriteString(j.kind + " ")
+
+	args, err = writeSQL(b, j.table, d)
+	if err != nil {
+		return
+	}
+
+	if j.on != nil {
+		b.WriteString(" ON ")
+		args, err = appendToSQL([]Sqlizer{j.on}, b, "", d, args)
+	}
+
+	return
+}
@@ -0,0 +1,40 @@
+package sqrl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinPartToSQL(t *testing.T) {
+	tests := []struct {
+		join Sqlizer
+		sql  string
+		args []interface{}
+	}{
+		{newJoinPart("JOIN", "b", "b.a_id = a.id"), "JOIN b ON b.a_id = a.id", nil},
+		{newJoinPart("JOIN", "b", nil), "JOIN b", nil},
+		{
+			newJoinPart("LEFT JOIN", "b", And{Expr("b.a_id = a.id"), Eq{"b.tenant": 1}}),
+			"LEFT JOIN b ON (b.a_id = a.id AND b.tenant = ?)",
+			[]interface{}{1},
+		},
+		{
+			newJoinPart("JOIN", derivedTable{query: Select("id").From("c").Where("x = ?", 1), alias: "c"}, "c.id = a.c_id AND c.y = ?", 2),
+			"JOIN (SELECT id FROM c WHERE x = ?) AS c ON c.id = a.c_id AND c.y = ?",
+			[]interface{}{1, 2},
+		},
+	}
+
+	for _, test := range tests {
+		b := &bytes.Buffer{}
+		args, err := test.join.WriteSQL(b)
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, b.String())
+		assert.Equal(t, test.args, args)
+	}
+
+	_, err := newJoinPart("JOIN", derivedTable{query: Select(), alias: "c"}, nil).WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,529 @@
+package sqrl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONPath is an element of a JSON document stored in a column. It is
+// rendered with the operators or functions of the dialect:
+//
+//	Generic, Postgres, SQLite: data->'a'->0
+//	MySQL:                     JSON_EXTRACT(data, '$.a[0]')
+//	SQLServer, Oracle:         JSON_QUERY(data, '$.a[0]')
+//
+// JSONPath can be used as a column, in Where and OrderByClause or as a value
+// of other expressions. Its Text, Eq, Op, Contains and HasKey methods build
+// the common predicates.
+type JSONPath struct {
+	column string
+	path   []interface{}
+	text   bool
+}
+
+// JSON returns the element of JSON document in column at path. Path
+// elements are object keys (strings) or array indexes (ints).
+// Ex:
+//
+//	.Column(JSON("data", "tags", 0))
+func JSON(column string, path ...interface{}) JSONPath {
+	return JSONPath{column: column, path: path}
+}
+
+// Text returns the element as a SQL scalar, i.e. unquoted text, instead of
+// a JSON value:
+//
+//	Generic, Postgres, SQLite: data->'a'->>'b'
+//	MySQL:                     JSON_UNQUOTE(JSON_EXTRACT(data, '$.a.b'))
+//	SQLServer, Oracle:         JSON_VALUE(data, '$.a.b')
+func (p JSONPath) Text() JSONPath {
+	p.text = true
+	return p
+}
+
+// WriteSQL implements Sqlizer.
+func (p JSONPath) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return p.writeDialectSQL(b, dialectOptions{})
+}
+
+func (p JSONPath) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	if err := checkJSONPath(p.path, d.Dialect); err != nil {
+		return nil, err
+	}
+
+	if len(p.path) == 0 {
+		if p.text {
+			return nil, errors.New("json path must have at least one element to be used as text")
+		}
+
+		b.WriteString(p.column)
+		return nil, nil
+	}
+
+	switch d.Dialect {
+	case MySQL:
+		if p.text {
+			b.WriteString("JSON_UNQUOTE(JSON_EXTRACT(" + p.column + ", " + jsonPathLiteral(p.path, d.Dialect) + "))")
+		} else {
+			b.WriteString("JSON_EXTRACT(" + p.column + ", " + jsonPathLiteral(p.path, d.Dialect) + ")")
+		}
+	case SQLServer, Oracle:
+		if p.text {
+			b.WriteString("JSON_VALUE(" + p.column + ", " + jsonPathLiteral(p.path, d.Dialect) + ")")
+		} else {
+			b.WriteString("JSON_QUERY(" + p.column + ", " + jsonPathLiteral(p.path, d.Dialect) + ")")
+		}
+	default:
+		b.WriteString(p.column)
+		for i, elem := range p.path {
+			if p.text && i == len(p.path)-1 {
+				b.WriteString("->>")
+			} else {
+				b.WriteString("->")
+			}
+
+			if key, ok := elem.(string); ok {
+				b.WriteString(sqlStringLiteral(key, d.Dialect))
+			} else {
+				b.WriteString(strconv.Itoa(elem.(int)))
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// Eq returns predicate "element = ?". Values are handled like in Eq: nil is
+// rendered as "IS NULL", a slice as "IN (...)" and a Sqlizer in place of the
+// placeholder.
+// Ex:
+//
+//	.Where(JSON("data", "status").Text().Eq("active")) == "data->>'status' = ?"
+func (p JSONPath) Eq(value interface{}) Sqlizer {
+	return jsonPredicate{path: p, operator: "=", value: value}
+}
+
+// Op returns predicate "element operator ?". Values are handled like in Op.
+// Ex:
+//
+//	.Where(JSON("data", "age").Text().Op(">", 18))
+func (p JSONPath) Op(operator string, value interface{}) Sqlizer {
+	return jsonPredicate{path: p, operator: operator, value: value}
+}
+
+// Contains returns predicate checking that the element contains value, which
+// is JSON encoded unless it's []byte or json.RawMessage. It is rendered as
+// "element @> ?::jsonb" for Postgres and Generic and as JSON_CONTAINS for
+// MySQL. Other dialects return an error.
+// Ex:
+//
+//	.Where(JSON("data", "tags").Contains([]string{"go"}))
+func (p JSONPath) Contains(value interface{}) Sqlizer {
+	return jsonContains{path: p, value: value}
+}
+
+// HasKey returns predicate checking that the element is an object with key,
+// e.g. "jsonb_exists(data->'a', ?)" for Postgres. The function is used instead
+// of the "?" operator, so the SQL doesn't depend on the placeholder format.
+func (p JSONPath) HasKey(key string) Sqlizer {
+	return jsonHasKey{path: p, key: key}
+}
+
+type jsonPredicate struct {
+	path     JSONPath
+	operator string
+	value    interface{}
+}
+
+// WriteSQL implements Sqlizer.
+func (e jsonPredicate) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return e.writeDialectSQL(b, dialectOptions{})
+}
+
+func (e jsonPredicate) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	switch e.value.(type) {
+	case Params, DriverParams:
+		return nil, errors.New("named parameters can't be used as a json element value")
+	}
+
+	// a path has no args, so it can be used as the column of Op
+	column := &bytes.Buffer{}
+	if _, err = e.path.writeDialectSQL(column, d); err != nil {
+		return
+	}
+
+	return Op{Column: column.String(), Operator: e.operator, Value: e.value}.writeDialectSQL(b, d)
+}
+
+type jsonContains struct {
+	path  JSONPath
+	value interface{}
+}
+
+// WriteSQL implements Sqlizer.
+func (e jsonContains) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return e.writeDialectSQL(b, dialectOptions{})
+}
+
+func (e jsonContains) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	doc, err := jsonArg(e.value)
+	if err != nil {
+		return
+	}
+
+	switch d.Dialect {
+	case Generic, Postgres:
+		if _, err = e.path.writeDialectSQL(b, d); err != nil {
+			return
+		}
+		b.WriteString(" @> ?::jsonb")
+	case MySQL:
+		if err = checkJSONPath(e.path.path, d.Dialect); err != nil {
+			return
+		}
+		b.WriteString("JSON_CONTAINS(" + e.path.column + ", ?")
+		if len(e.path.path) > 0 {
+			b.WriteString(", " + jsonPathLiteral(e.path.path, d.Dialect))
+		}
+		b.WriteByte(')')
+	default:
+		return nil, fmt.Errorf("json containment is not supported by %s dialect", d.Dialect)
+	}
+
+	return []interface{}{doc}, nil
+}
+
+type jsonHasKey struct {
+	path JSONPath
+	key  string
+}
+
+// WriteSQL implements Sqlizer.
+func (e jsonHasKey) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return e.writeDialectSQL(b, dialectOptions{})
+}
+
+func (e jsonHasKey) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	if err = checkJSONPath(e.path.path, d.Dialect); err != nil {
+		return
+	}
+
+	keyPath := make([]interface{}, 0, len(e.path.path)+1)
+	keyPath = append(append(keyPath, e.path.path...), e.key)
+	path := jsonPathLiteral(keyPath, d.Dialect)
+
+	switch d.Dialect {
+	case MySQL:
+		b.WriteString("JSON_CONTAINS_PATH(" + e.path.column + ", 'one', " + path + ")")
+	case SQLite:
+		b.WriteString("json_type(" + e.path.column + ", " + path + ") IS NOT NULL")
+	case SQLServer:
+		b.WriteString("JSON_PATH_EXISTS(" + e.path.column + ", " + path + ") = 1")
+	case Oracle:
+		b.WriteString("JSON_EXISTS(" + e.path.column + ", " + path + ")")
+	default:
+		b.WriteString("jsonb_exists(")
+		if _, err = e.path.writeDialectSQL(b, d); err != nil {
+			return
+		}
+		b.WriteString(", ?)")
+		return []interface{}{e.key}, nil
+	}
+
+	return nil, nil
+}
+
+type jsonUpdateOp int
+
+const (
+	jsonSet jsonUpdateOp = iota
+	jsonRemove
+	jsonAppend
+)
+
+// jsonUpdate is a JSON document in column with the element at path set,
+// removed or appended to. If hasDoc is true, doc is the previous value of the
+// column set in the same statement, e.g. another jsonUpdate, which is updated
+// instead of the column.
+type jsonUpdate struct {
+	column string
+	doc    interface{}
+	hasDoc bool
+	op     jsonUpdateOp
+	path   []interface{}
+	value  interface{}
+}
+
+// WriteSQL implements Sqlizer.
+func (u jsonUpdate) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return u.writeDialectSQL(b, dialectOptions{})
+}
+
+func (u jsonUpdate) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	if err = checkJSONPath(u.path, d.Dialect); err != nil {
+		return
+	}
+	if len(u.path) == 0 && u.op != jsonAppend {
+		return nil, errors.New("json path must have at least one element to be set or removed")
+	}
+
+	var fn, sep, valueSQL, end string
+	var valueArgs []interface{}
+
+	if u.op != jsonRemove {
+		if valueSQL, valueArgs, err = jsonValueToSQL(u.value, d, false); err != nil {
+			return
+		}
+	}
+
+	switch d.Dialect {
+	case Generic, Postgres:
+		path := jsonTextArrayLiteral(u.path)
+		switch u.op {
+		case jsonSet:
+			fn, sep, end = "jsonb_set(", ", "+path+", "+valueSQL, ")"
+		case jsonRemove:
+			sep = " #- " + path
+		case jsonAppend:
+			path = jsonTextArrayLiteral(append(u.path[:len(u.path):len(u.path)], -1))
+			fn, sep, end = "jsonb_insert(", ", "+path+", "+valueSQL+", true", ")"
+		}
+	case MySQL, SQLite:
+		path := jsonPathLiteral(u.path, d.Dialect)
+		switch u.op {
+		case jsonSet:
+			fn, sep = "JSON_SET(", ", "+path+", "+valueSQL
+		case jsonRemove:
+			fn, sep = "JSON_REMOVE(", ", "+path
+		case jsonAppend:
+			fn, sep = "JSON_ARRAY_APPEND(", ", "+path+", "+valueSQL
+			if d.Dialect == SQLite {
+				// SQLite appends by inserting past the last element: '$.a[#]'
+				fn, sep = "JSON_INSERT(", ", "+path[:len(path)-1]+"[#]', "+valueSQL
+			}
+		}
+		if d.Dialect == SQLite {
+			fn = strings.ToLower(fn)
+		}
+		end = ")"
+	case Oracle:
+		path := jsonPathLiteral(u.path, d.Dialect)
+		switch u.op {
+		case jsonSet:
+			sep = ", SET " + path + " = " + valueSQL
+		case jsonRemove:
+			sep = ", REMOVE " + path
+		case jsonAppend:
+			sep = ", APPEND " + path + " = " + valueSQL
+		}
+		fn, end = "JSON_TRANSFORM(", ")"
+	case SQLServer:
+		// JSON_MODIFY deletes the element set to NULL unless the path is
+		// strict, which in turn requires the element to exist
+		var mode string
+		switch {
+		case u.op == jsonAppend:
+			mode = "append "
+		case u.op == jsonRemove:
+			valueSQL = "NULL"
+		}
+		if u.op != jsonRemove && valueSQL == "NULL" {
+			mode += "strict "
+		}
+
+		path := jsonPathLiteral(u.path, d.Dialect)
+		fn, sep, end = "JSON_MODIFY(", ", '"+mode+path[1:]+", "+valueSQL, ")"
+	}
+
+	b.WriteString(fn)
+	if u.hasDoc {
+		var docSQL string
+		if docSQL, args, err = jsonValueToSQL(u.doc, d, true); err != nil {
+			return
+		}
+		b.WriteString(docSQL)
+	} else {
+		b.WriteString(u.column)
+	}
+	b.WriteString(sep + end)
+
+	return append(args, valueArgs...), nil
+}
+
+// jsonValueToSQL returns value cast from JSON text to the JSON type of the
+// dialect. Sqlizer values are rendered as they are. If doc is true, the value
+// is a document to be updated rather than a new element.
+func jsonValueToSQL(value interface{}, d dialectOptions, doc bool) (string, []interface{}, error) {
+	if s, ok := value.(Sqlizer); ok {
+		b := &bytes.Buffer{}
+		args, err := writeSQL(b, s, d)
+		return b.String(), args, err
+	}
+
+	text, err := jsonArg(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch d.Dialect {
+	case MySQL:
+		return "CAST(? AS JSON)", []interface{}{text}, nil
+	case SQLite:
+		return "json(?)", []interface{}{text}, nil
+	case Oracle:
+		if doc {
+			return "JSON(?)", []interface{}{text}, nil
+		}
+		return "? FORMAT JSON", []interface{}{text}, nil
+	case SQLServer:
+		if doc {
+			return "?", []interface{}{text}, nil
+		}
+		return sqlServerJSONValue(value, text)
+	}
+
+	return "?::jsonb", []interface{}{text}, nil
+}
+
+// sqlServerJSONValue returns a new element for JSON_MODIFY. Objects and
+// arrays are passed through JSON_QUERY, so they are not escaped as a string.
+// JSON_QUERY can't return scalars, which are bound as they are instead,
+// decoded first if value is JSON text.
+func sqlServerJSONValue(value interface{}, text string) (string, []interface{}, error) {
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return "JSON_QUERY(?)", []interface{}{text}, nil
+	}
+
+	switch value.(type) {
+	case []byte, json.RawMessage:
+		value = nil
+		if err := json.Unmarshal([]byte(text), &value); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if value == nil {
+		return "NULL", nil, nil
+	}
+
+	return "?", []interface{}{value}, nil
+}
+
+// jsonArg returns value encoded as JSON text.
+func jsonArg(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case []byte:
+		return string(v), nil
+	case json.RawMessage:
+		return string(v), nil
+	}
+
+	doc, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(doc), nil
+}
+
+// checkJSONPath returns an error if path has elements other than strings and
+// ints. Negative indexes count from the end of an array, which only Postgres
+// and SQLite support.
+func checkJSONPath(path []interface{}, d Dialect) error {
+	for _, elem := range path {
+		switch v := elem.(type) {
+		case string:
+		case int:
+			if v < 0 && d != Generic && d != Postgres && d != SQLite {
+				return fmt.Errorf("negative json array index is not supported by %s dialect", d)
+			}
+		default:
+			return fmt.Errorf("json path element must be a string or an int, not %T", elem)
+		}
+	}
+	return nil
+}
+
+// jsonPathLiteral returns SQL/JSON path string literal, e.g. '$.a[0]."b c"'.
+func jsonPathLiteral(path []interface{}, d Dialect) string {
+	var b bytes.Buffer
+
+	b.WriteByte('$')
+	for _, elem := range path {
+		key, ok := elem.(string)
+		if !ok {
+			// SQLite counts from the end of an array with [#-N]
+			if i := elem.(int); i < 0 && d == SQLite {
+				b.WriteString("[#" + strconv.Itoa(i) + "]")
+			} else {
+				b.WriteString("[" + strconv.Itoa(i) + "]")
+			}
+			continue
+		}
+
+		b.WriteByte('.')
+		if isSimpleJSONKey(key) {
+			b.WriteString(key)
+		} else {
+			// JSON string escapes are valid in SQL/JSON path member names
+			quoted, _ := json.Marshal(key)
+			b.WriteString(string(quoted))
+		}
+	}
+
+	return sqlStringLiteral(b.String(), d)
+}
+
+// jsonTextArrayLiteral returns Postgres text array literal of path, e.g.
+// '{a,0,"b c"}'.
+func jsonTextArrayLiteral(path []interface{}) string {
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+	for i, elem := range path {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		key, ok := elem.(string)
+		if !ok {
+			b.WriteString(strconv.Itoa(elem.(int)))
+		} else if isSimpleJSONKey(key) && !strings.EqualFold(key, "null") {
+			b.WriteString(key)
+		} else {
+			key = strings.Replace(key, `\`, `\\`, -1)
+			b.WriteString(`"` + strings.Replace(key, `"`, `\"`, -1) + `"`)
+		}
+	}
+	b.WriteByte('}')
+
+	return sqlStringLiteral(b.String(), Postgres)
+}
+
+func isSimpleJSONKey(key string) bool {
+	if len(key) == 0 || key[0] >= '0' && key[0] <= '9' {
+		return false
+	}
+
+	for i := 0; i < len(key); i++ {
+		if !isIdentByte(key[i]) || key[i] >= 0x80 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sqlStringLiteral quotes s as a SQL string literal. MySQL also treats
+// backslash as an escape character.
+func sqlStringLiteral(s string, d Dialect) string {
+	if d == MySQL {
+		s = strings.Replace(s, `\`, `\\`, -1)
+	}
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
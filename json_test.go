@@ -0,0 +1,109 @@
+package sqrl
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONPath(t *testing.T) {
+	cases := []struct {
+		d        Dialect
+		path     JSONPath
+		expected string
+	}{
+		{Postgres, JSON("data", "a", 0, "b"), "data->'a'->0->'b'"},
+		{Postgres, JSON("data", "a", "it's").Text(), "data->'a'->>'it''s'"},
+		{SQLite, JSON("data", "a").Text(), "data->>'a'"},
+		{MySQL, JSON("data", "a", 0, "b c"), `JSON_EXTRACT(data, '$.a[0]."b c"')`},
+		{MySQL, JSON("data", "a").Text(), "JSON_UNQUOTE(JSON_EXTRACT(data, '$.a'))"},
+		{SQLServer, JSON("data", "a", "b"), "JSON_QUERY(data, '$.a.b')"},
+		{Oracle, JSON("data", "a", "b").Text(), "JSON_VALUE(data, '$.a.b')"},
+		{Postgres, JSON("data"), "data"},
+		{Postgres, JSON("data", "a", -1), "data->'a'->-1"},
+		{MySQL, JSON("data", "a\x01"), `JSON_EXTRACT(data, '$."a\\u0001"')`},
+	}
+
+	for _, c := range cases {
+		sql, _, err := Select().Column(c.path).From("t").Dialect(c.d).ToSQL()
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT "+c.expected+" FROM t", sql)
+	}
+
+	_, err := JSON("data").Text().WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+
+	_, err = JSON("data", 1.5).WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+
+	_, _, err = Select().Column(JSON("data", "a", -1)).From("t").Dialect(MySQL).ToSQL()
+	assert.Error(t, err)
+
+	sql, _, err := Update("t").RemoveJSONPath("data", "tags", -1).Dialect(SQLite).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE t SET data = json_remove(data, '$.tags[#-1]')", sql)
+}
+
+func TestJSONPredicates(t *testing.T) {
+	q := func() *SelectBuilder {
+		return Select("id").
+			From("t").
+			Where(JSON("data", "status").Text().Eq("active")).
+			Where(JSON("data", "deleted_at").Eq(nil)).
+			Where(JSON("data", "tags").Contains([]string{"go"})).
+			Where(JSON("data", "owner").HasKey("id")).
+			OrderByClause(JSON("data", "rank").Text())
+	}
+
+	sql, args, err := q().Dialect(Postgres).PlaceholderFormat(Dollar).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM t WHERE data->>'status' = $1 AND data->'deleted_at' IS NULL AND data->'tags' @> $2::jsonb AND jsonb_exists(data->'owner', $3) ORDER BY data->>'rank'", sql)
+	assert.Equal(t, []interface{}{"active", `["go"]`, "id"}, args)
+
+	sql, args, err = q().Dialect(MySQL).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM t WHERE JSON_UNQUOTE(JSON_EXTRACT(data, '$.status')) = ? AND JSON_EXTRACT(data, '$.deleted_at') IS NULL AND JSON_CONTAINS(data, ?, '$.tags') AND JSON_CONTAINS_PATH(data, 'one', '$.owner.id') ORDER BY JSON_UNQUOTE(JSON_EXTRACT(data, '$.rank'))", sql)
+	assert.Equal(t, []interface{}{"active", `["go"]`}, args)
+
+	sql, _, err = Select("id").From("t").Where(JSON("data").HasKey("a")).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM t WHERE jsonb_exists(data, ?)", sql)
+
+	_, _, err = q().Dialect(SQLite).ToSQL()
+	assert.Error(t, err)
+
+	sql, args, err = Select("id").From("t").Where(JSON("data", "age").Text().Op(">", 18)).Where(JSON("data").HasKey("a")).Dialect(SQLServer).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM t WHERE JSON_VALUE(data, '$.age') > ? AND JSON_PATH_EXISTS(data, '$.a') = 1", sql)
+	assert.Equal(t, []interface{}{18}, args)
+
+	b := &bytes.Buffer{}
+	args, err = JSON("data").Contains(json.RawMessage(`{"a":1}`)).WriteSQL(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "data @> ?::jsonb", b.String())
+	assert.Equal(t, []interface{}{`{"a":1}`}, args)
+}
+
+func TestJSONPredicateValues(t *testing.T) {
+	sql, args, err := Select("id").
+		From("t").
+		Where(JSON("data", "n").Text().Eq([]int{1, 2})).
+		Where(JSON("data", "m").Text().Eq(Expr("other"))).
+		Where(JSON("data", "k").Text().Op("<>", nil)).
+		Where(JSON("data", "u").Text().Eq(Select("name").From("users"))).
+		Dialect(Postgres).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM t WHERE data->>'n' IN ($1,$2) AND data->>'m' = other AND data->>'k' IS NOT NULL AND data->>'u' IN (SELECT name FROM users)", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	b := &bytes.Buffer{}
+	_, err = JSON("data", "n").Op(">", []int{1}).WriteSQL(b)
+	assert.Error(t, err)
+
+	_, err = JSON("data", "n").Eq(Params{"a": 1}).WriteSQL(b)
+	assert.Error(t, err)
+}
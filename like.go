@@ -0,0 +1,183 @@
+package sqrl
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Like is syntactic sugar for use with Where/Having methods.
+// Values are either plain patterns or results of Contains, StartsWith and
+// EndsWith which escape wildcards of user input. Sqlizer values are rendered
+// in place of the placeholder, like in Op.
+// Ex:
+//
+//	.Where(Like{"name": "a%"}) == "name LIKE ?"
+//	.Where(Like{"name": Contains("50%")}) == "name LIKE ? ESCAPE '!'"
+type Like map[string]interface{}
+
+// WriteSQL implements Sqlizer.
+func (lk Like) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return lk.writeDialectSQL(b, dialectOptions{})
+}
+
+func (lk Like) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return likeToSQL(sortedPairs(lk), b, d, false, false)
+}
+
+// NotLike is syntactic sugar for use with Where/Having methods.
+// Ex:
+//
+//	.Where(NotLike{"name": "a%"}) == "name NOT LIKE ?"
+type NotLike map[string]interface{}
+
+// WriteSQL implements Sqlizer.
+func (nlk NotLike) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return nlk.writeDialectSQL(b, dialectOptions{})
+}
+
+func (nlk NotLike) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return likeToSQL(sortedPairs(nlk), b, d, true, false)
+}
+
+// ILike is a case insensitive Like. It is rendered as ILIKE for Postgres
+// dialect and as "LOWER(column) LIKE LOWER(?)" for other dialects.
+// Ex:
+//
+//	// Postgres
+//	.Where(ILike{"name": StartsWith("jo")}) == "name ILIKE ? ESCAPE '!'"
+//	// Generic and other dialects
+//	.Where(ILike{"name": StartsWith("jo")}) == "LOWER(name) LIKE LOWER(?) ESCAPE '!'"
+type ILike map[string]interface{}
+
+// WriteSQL implements Sqlizer.
+func (ilk ILike) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return ilk.writeDialectSQL(b, dialectOptions{})
+}
+
+func (ilk ILike) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return likeToSQL(sortedPairs(ilk), b, d, false, true)
+}
+
+// NotILike is a case insensitive NotLike.
+type NotILike map[string]interface{}
+
+// WriteSQL implements Sqlizer.
+func (nilk NotILike) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return nilk.writeDialectSQL(b, dialectOptions{})
+}
+
+func (nilk NotILike) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return likeToSQL(sortedPairs(nilk), b, d, true, true)
+}
+
+// Search is a case insensitive search of the pattern in any of the columns,
+// see ILike: "(a ILIKE ? OR b ILIKE ?)" for Postgres. The pattern is usually a
+// result of Contains.
+// Ex:
+//
+//	.Where(Search(Contains(q), "name", "email"))
+func Search(pattern interface{}, columns ...string) Sqlizer {
+	or := make(Or, len(columns))
+	for i, column := range columns {
+		or[i] = ILike{column: pattern}
+	}
+	return or
+}
+
+// likePattern is a LIKE pattern built from a literal string, which is
+// escaped when rendered.
+type likePattern struct {
+	value     string
+	anyPrefix bool
+	anySuffix bool
+}
+
+// Contains returns a LIKE pattern matching strings containing s. Wildcards
+// in s are escaped, so it's safe to use with user input.
+func Contains(s string) likePattern {
+	return likePattern{value: s, anyPrefix: true, anySuffix: true}
+}
+
+// StartsWith returns a LIKE pattern matching strings starting with s.
+// Wildcards in s are escaped.
+func StartsWith(s string) likePattern {
+	return likePattern{value: s, anySuffix: true}
+}
+
+// EndsWith returns a LIKE pattern matching strings ending with s.
+// Wildcards in s are escaped.
+func EndsWith(s string) likePattern {
+	return likePattern{value: s, anyPrefix: true}
+}
+
+// pattern returns the escaped pattern. SQL Server also treats brackets as
+// wildcards.
+func (p likePattern) pattern(d Dialect) string {
+	var b bytes.Buffer
+
+	if p.anyPrefix {
+		b.WriteByte('%')
+	}
+
+	for i := 0; i < len(p.value); i++ {
+		c := p.value[i]
+		if c == '!' || c == '%' || c == '_' || c == '[' && d == SQLServer {
+			b.WriteByte('!')
+		}
+		b.WriteByte(c)
+	}
+
+	if p.anySuffix {
+		b.WriteByte('%')
+	}
+
+	return b.String()
+}
+
+// likeEscape is ESCAPE clause of escaped patterns. Backslash would need
+// different quoting in MySQL string literals, so "!" is used instead.
+const likeEscape = ` ESCAPE '!'`
+
+func likeToSQL(pairs []Pair, b *bytes.Buffer, d dialectOptions, useNotOpr, caseInsensitive bool) (args []interface{}, err error) {
+	opr := "LIKE"
+	if useNotOpr {
+		opr = "NOT LIKE"
+	}
+
+	lower := caseInsensitive && d.Dialect != Postgres
+	if caseInsensitive && !lower {
+		opr = strings.Replace(opr, "LIKE", "ILIKE", 1)
+	}
+
+	for i, p := range pairs {
+		if i > 0 {
+			b.WriteString(" AND ")
+		}
+
+		var (
+			sql     string
+			valArgs []interface{}
+			escape  bool
+		)
+
+		if pattern, ok := p.Value.(likePattern); ok {
+			sql, valArgs, escape = "?", []interface{}{pattern.pattern(d.Dialect)}, true
+		} else if sql, valArgs, err = operandValueToSQL(p.Value, d, opr); err != nil {
+			return
+		}
+
+		if lower {
+			b.WriteString("LOWER(" + p.Column + ") " + opr + " LOWER(" + sql + ")")
+		} else {
+			b.WriteString(p.Column + " " + opr + " " + sql)
+		}
+
+		if escape {
+			b.WriteString(likeEscape)
+		}
+
+		args = append(args, valArgs...)
+	}
+
+	return
+}
@@ -0,0 +1,79 @@
+package sqrl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLike(t *testing.T) {
+	b := &bytes.Buffer{}
+	args, err := Like{"b": "x%", "a": Contains(`50%_!\`)}.WriteSQL(b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `a LIKE ? ESCAPE '!' AND b LIKE ?`, b.String())
+	assert.Equal(t, []interface{}{`%50!%!_!!\%`, "x%"}, args)
+
+	b.Reset()
+	args, err = NotLike{"a": StartsWith("a_b")}.WriteSQL(b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `a NOT LIKE ? ESCAPE '!'`, b.String())
+	assert.Equal(t, []interface{}{`a!_b%`}, args)
+
+	_, err = Like{"a": nil}.WriteSQL(b)
+	assert.Error(t, err)
+
+	_, err = Like{"a": []string{"x"}}.WriteSQL(b)
+	assert.Error(t, err)
+
+	b.Reset()
+	args, err = Like{"a": Expr("b || ?", "%")}.WriteSQL(b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a LIKE b || ?", b.String())
+	assert.Equal(t, []interface{}{"%"}, args)
+
+	sql, args, err := Select("id").From("t").Where(ILike{"a": Expr("CONCAT(b, ?)", "%")}).Dialect(MySQL).ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM t WHERE LOWER(a) LIKE LOWER(CONCAT(b, ?))", sql)
+	assert.Equal(t, []interface{}{"%"}, args)
+}
+
+func TestILikeDialects(t *testing.T) {
+	q := Select("id").From("users").Where(ILike{"name": EndsWith("[x]")})
+
+	sql, args, err := q.Dialect(Postgres).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id FROM users WHERE name ILIKE ? ESCAPE '!'`, sql)
+	assert.Equal(t, []interface{}{"%[x]"}, args)
+
+	sql, args, err = q.Dialect(SQLServer).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id FROM users WHERE LOWER(name) LIKE LOWER(?) ESCAPE '!'`, sql)
+	assert.Equal(t, []interface{}{`%![x]`}, args)
+
+	sql, _, err = q.Dialect(MySQL).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id FROM users WHERE LOWER(name) LIKE LOWER(?) ESCAPE '!'`, sql)
+
+	sql, _, err = Select("id").From("users").Where(NotILike{"name": "a%"}).Dialect(Postgres).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE name NOT ILIKE ?", sql)
+}
+
+func TestSearch(t *testing.T) {
+	sql, args, err := Select("id").
+		From("users").
+		Where(Search(Contains("jo"), "name", "email")).
+		Where(Eq{"active": true}).
+		Dialect(Postgres).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id FROM users WHERE (name ILIKE $1 ESCAPE '!' OR email ILIKE $2 ESCAPE '!') AND active = $3`, sql)
+	assert.Equal(t, []interface{}{"%jo%", "%jo%", true}, args)
+}
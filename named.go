@@ -0,0 +1,211 @@
+package sqrl
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Params binds named placeholders like :name or @name in Expr and in string
+// expressions of the builders (Where, Having, Column, Join etc.). Each
+// occurrence of a name is replaced with a ? placeholder bound to its value, so
+// a value can be reused many times and numbering of placeholder formats like
+// Dollar stays correct.
+//
+// Ex:
+//
+//	.Where("tenant_id = :tenant AND (owner = :user OR editor = :user)",
+//		Params{"tenant": 1, "user": 2})
+//
+// Named values can also be passed as sql.Named args or taken from a struct
+// with StructParams.
+type Params map[string]interface{}
+
+// DriverParams binds named placeholders the same way as Params, but keeps the
+// placeholders as written and passes each value once as sql.NamedArg. Use it
+// with drivers that support named parameters natively.
+//
+// Ex:
+//
+//	.Where("tenant_id = @tenant", DriverParams{"tenant": 1})
+//	// "tenant_id = @tenant", []interface{}{sql.Named("tenant", 1)}
+type DriverParams map[string]interface{}
+
+// StructParams returns Params with exported fields of struct v, which may be
+// a pointer to struct. A field is named by its "db" tag or by its name;
+// fields tagged with "-" are skipped.
+func StructParams(v interface{}) Params {
+	val := reflect.Indirect(reflect.ValueOf(v))
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	params := Params{}
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		if tag := field.Tag.Get("db"); tag != "" {
+			name = strings.Split(tag, ",")[0]
+		}
+		if name == "-" {
+			continue
+		}
+
+		params[name] = val.Field(i).Interface()
+	}
+
+	return params
+}
+
+// namedArgs returns named values if args bind named placeholders: a single
+// Params or DriverParams, or sql.NamedArg values only.
+func namedArgs(args []interface{}) (params map[string]interface{}, driver bool, ok bool) {
+	if len(args) == 0 {
+		return
+	}
+
+	if len(args) == 1 {
+		switch p := args[0].(type) {
+		case Params:
+			return p, false, true
+		case DriverParams:
+			return p, true, true
+		}
+	}
+
+	params = make(map[string]interface{}, len(args))
+	for _, arg := range args {
+		named, isNamed := arg.(sql.NamedArg)
+		if !isNamed {
+			return nil, false, false
+		}
+		params[named.Name] = named.Value
+	}
+
+	return params, false, true
+}
+
+// writeNamedSQL writes sql binding named placeholders to params. Sqlizer
+// values are rendered for dialect d.
+func writeNamedSQL(b *bytes.Buffer, query string, params map[string]interface{}, driver bool, d dialectOptions) ([]interface{}, error) {
+	var (
+		args []interface{}
+		seen map[string]bool
+	)
+
+	sql, err := lexSQL(query, d.Dialect, func(buf *bytes.Buffer, p int) (int, error) {
+		c := query[p]
+		if c == '?' {
+			return skipPositional(buf, query, p)
+		}
+		if c != ':' && c != '@' {
+			return p, nil
+		}
+
+		// skip casts (::) and variables (@@) as well as identifiers like
+		// a:b or user@host
+		if p > 0 && (query[p-1] == c || isIdentByte(query[p-1])) {
+			return p, nil
+		}
+
+		end := p + 1
+		for end < len(query) && isIdentByte(query[end]) {
+			end++
+		}
+		if end == p+1 || query[p+1] >= '0' && query[p+1] <= '9' {
+			return p, nil
+		}
+
+		name := query[p+1 : end]
+		value, ok := params[name]
+		if !ok {
+			return p, fmt.Errorf("no value for named parameter %s", query[p:end])
+		}
+
+		if driver {
+			buf.WriteString(query[p:end])
+			if !seen[name] {
+				if seen == nil {
+					seen = map[string]bool{}
+				}
+				seen[name] = true
+				args = append(args, sql.Named(name, value))
+			}
+			return end, nil
+		}
+
+		switch v := value.(type) {
+		case Sqlizer:
+			vs, err := writeSQL(buf, v, d)
+			if err != nil {
+				return p, err
+			}
+			args = append(args, vs...)
+		default:
+			buf.WriteByte('?')
+			args = append(args, v)
+		}
+
+		return end, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b.WriteString(sql)
+	return args, nil
+}
+
+// skipPositional copies escaped "??" and Postgres operators ?| and ?& at
+// query[p]. Any other question mark is a positional placeholder, which would
+// be left without a value, so it is an error.
+func skipPositional(buf *bytes.Buffer, query string, p int) (int, error) {
+	switch {
+	case strings.HasPrefix(query[p:], "??"):
+		buf.WriteString("??")
+		return p + 2, nil
+	case strings.HasPrefix(query[p:], "?&"),
+		strings.HasPrefix(query[p:], "?|") && !strings.HasPrefix(query[p:], "?||"):
+		buf.WriteString(query[p : p+2])
+		return p + 2, nil
+	}
+
+	return p, errors.New("cannot use ? placeholders together with named parameters")
+}
+
+// namedArgsLast moves sql.NamedArg values to the end of args, so ordinals of
+// positional args match their placeholders.
+func namedArgsLast(args []interface{}) []interface{} {
+	n := 0
+	for _, arg := range args {
+		if _, ok := arg.(sql.NamedArg); ok {
+			n++
+		}
+	}
+
+	if n == 0 || n == len(args) {
+		return args
+	}
+
+	sorted := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		if _, ok := arg.(sql.NamedArg); !ok {
+			sorted = append(sorted, arg)
+		}
+	}
+	for _, arg := range args {
+		if _, ok := arg.(sql.NamedArg); ok {
+			sorted = append(sorted, arg)
+		}
+	}
+
+	return sorted
+}
@@ -0,0 +1,111 @@
+package sqrl
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExprParams(t *testing.T) {
+	b := &bytes.Buffer{}
+	args, err := Expr(
+		"tenant = :tenant AND (owner = :user OR editor = @user) AND note <> ':user?' AND t::date ?? d AND a[lo:hi] AND x = :1",
+		Params{"tenant": 1, "user": 2},
+	).WriteSQL(b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant = ? AND (owner = ? OR editor = ?) AND note <> ':user?' AND t::date ?? d AND a[lo:hi] AND x = :1", b.String())
+	assert.Equal(t, []interface{}{1, 2, 2}, args)
+
+	// a positional placeholder can't be bound together with named ones
+	_, err = Expr("tenant = :tenant AND t::date = ?", Params{"tenant": 1}).WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+
+	_, _, err = Select("*").From("t").Where("x = ? AND y = :y", Params{"y": 1}).PlaceholderFormat(Dollar).ToSQL()
+	assert.Error(t, err)
+}
+
+func TestExprSQLNamed(t *testing.T) {
+	b := &bytes.Buffer{}
+	args, err := Expr("a = :x OR b = :x OR c IN (:sub)",
+		sql.Named("x", 1),
+		sql.Named("sub", Select("id").From("t").Where("y = ?", 2)),
+	).WriteSQL(b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a = ? OR b = ? OR c IN (SELECT id FROM t WHERE y = ?)", b.String())
+	assert.Equal(t, []interface{}{1, 1, 2}, args)
+}
+
+func TestExprParamsMissing(t *testing.T) {
+	_, err := Expr("a = :x AND b = :y", Params{"x": 1}).WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestStructParams(t *testing.T) {
+	type filter struct {
+		TenantID int `db:"tenant_id"`
+		Status   string
+		Skipped  int `db:"-"`
+		private  int
+	}
+
+	params := StructParams(&filter{TenantID: 1, Status: "active", Skipped: 2, private: 3})
+	assert.Equal(t, Params{"tenant_id": 1, "Status": "active"}, params)
+	assert.Nil(t, StructParams(1))
+}
+
+func TestNamedParamsInBuilders(t *testing.T) {
+	p := Params{"tenant": 7, "min": 10}
+
+	sql, args, err := Select("a").
+		Column("COUNT(*) FILTER (WHERE tenant_id = :tenant)", p).
+		From("t").
+		Where("x = ?", 1).
+		Where("tenant_id = :tenant AND total > :min", p).
+		Having("SUM(total) > :min", p).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a, COUNT(*) FILTER (WHERE tenant_id = $1) FROM t WHERE x = $2 AND tenant_id = $3 AND total > $4 HAVING SUM(total) > $5", sql)
+	assert.Equal(t, []interface{}{7, 1, 7, 10, 10}, args)
+}
+
+func TestDriverParams(t *testing.T) {
+	query, args, err := Select("a").
+		From("t").
+		Where("tenant_id = @tenant OR owner_tenant_id = @tenant", DriverParams{"tenant": 7}).
+		Where("b = ?", 1).
+		PlaceholderFormat(AtP).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM t WHERE tenant_id = @tenant OR owner_tenant_id = @tenant AND b = @p1", query)
+	assert.Equal(t, []interface{}{1, sql.Named("tenant", 7)}, args)
+}
+
+func TestPrefixSuffixParams(t *testing.T) {
+	sql, args, err := Select("a").
+		Prefix("/* :note */ WITH x AS (SELECT :v)", Params{"v": 1}).
+		From("t").
+		Suffix("LIMIT :n", Params{"n": 2}).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "/* :note */ WITH x AS (SELECT $1) SELECT a FROM t LIMIT $2", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	sql, args, err = Update("t").Set("a", 1).Suffix("RETURNING :x", Params{"x": Expr("id")}).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE t SET a = ? RETURNING id", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	_, _, err = Delete("t").Prefix("SET :missing").ToSQL()
+	assert.NoError(t, err)
+
+	_, _, err = Insert("t").Values(1).Suffix("RETURNING :x", Params{}).ToSQL()
+	assert.Error(t, err)
+}
@@ -0,0 +1,98 @@
+package sqrl
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// pagination holds LIMIT and OFFSET of a statement, which are spelled
+// differently across dialects.
+type pagination struct {
+	limit       uint64
+	limitValid  bool
+	offset      uint64
+	offsetValid bool
+}
+
+// useTop reports whether the row limit is rendered as SQL Server "TOP (n)"
+// right after the statement keyword instead of a trailing clause.
+func (p pagination) useTop(d Dialect) bool {
+	return d == SQLServer && p.limitValid && !p.offsetValid
+}
+
+// writeTop writes "TOP (n) " clause.
+func (p pagination) writeTop(b *bytes.Buffer) {
+	b.WriteString("TOP (" + strconv.FormatUint(p.limit, 10) + ") ")
+}
+
+// writeSelectSQL writes trailing pagination clause of a query, either
+// "LIMIT n OFFSET m" or "OFFSET m ROWS FETCH NEXT n ROWS ONLY" for SQL Server
+// and Oracle. SQL Server requires ORDER BY for OFFSET, so an arbitrary order
+// is added unless the query is ordered. top tells that the limit has been
+// already written with writeTop.
+func (p pagination) writeSelectSQL(b *bytes.Buffer, d Dialect, ordered, top bool) {
+	if top {
+		return
+	}
+
+	switch d {
+	case SQLServer, Oracle:
+		if !p.limitValid && !p.offsetValid {
+			return
+		}
+
+		offset := p.offsetValid || d == SQLServer
+		if offset {
+			if d == SQLServer && !ordered {
+				b.WriteString(" ORDER BY (SELECT NULL)")
+			}
+			b.WriteString(" OFFSET " + strconv.FormatUint(p.offset, 10) + " ROWS")
+		}
+
+		if p.limitValid {
+			if offset {
+				b.WriteString(" FETCH NEXT ")
+			} else {
+				b.WriteString(" FETCH FIRST ")
+			}
+			b.WriteString(strconv.FormatUint(p.limit, 10) + " ROWS ONLY")
+		}
+	default:
+		p.writeLimitSQL(b)
+	}
+}
+
+// writeLimitSQL writes " LIMIT n OFFSET m" clause.
+func (p pagination) writeLimitSQL(b *bytes.Buffer) {
+	if p.limitValid {
+		b.WriteString(" LIMIT " + strconv.FormatUint(p.limit, 10))
+	}
+
+	if p.offsetValid {
+		b.WriteString(" OFFSET " + strconv.FormatUint(p.offset, 10))
+	}
+}
+
+// checkModify returns an error if the dialect can't express the pagination
+// of UPDATE or DELETE statement. SQL Server doesn't allow ORDER BY there, so
+// TOP (n) can't limit ordered rows.
+func (p pagination) checkModify(d Dialect, statement string, ordered bool) error {
+	switch d {
+	case Postgres, Oracle:
+		if p.limitValid || p.offsetValid {
+			return fmt.Errorf("%s dialect does not support LIMIT and OFFSET in %s statements", d, statement)
+		}
+	case SQLServer:
+		if ordered {
+			return fmt.Errorf("%s dialect does not support ORDER BY in %s statements", d, statement)
+		}
+		fallthrough
+	case MySQL:
+		if p.offsetValid {
+			return fmt.Errorf("%s dialect does not support OFFSET in %s statements", d, statement)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectPagination(t *testing.T) {
+	cases := []struct {
+		d        Dialect
+		b        *SelectBuilder
+		expected string
+	}{
+		{Postgres, Select("a").From("t").OrderBy("a").Limit(10).Offset(20), "SELECT a FROM t ORDER BY a LIMIT 10 OFFSET 20"},
+		{SQLServer, Select("a").Distinct().From("t").OrderBy("a").Limit(10), "SELECT DISTINCT TOP (10) a FROM t ORDER BY a"},
+		{SQLServer, Select("a").From("t").OrderBy("a").Limit(10).Offset(20), "SELECT a FROM t ORDER BY a OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"},
+		{SQLServer, Select("a").From("t").Offset(20), "SELECT a FROM t ORDER BY (SELECT NULL) OFFSET 20 ROWS"},
+		{Oracle, Select("a").From("t").Limit(10), "SELECT a FROM t FETCH FIRST 10 ROWS ONLY"},
+		{Oracle, Select("a").From("t").OrderBy("a").Limit(10).Offset(20), "SELECT a FROM t ORDER BY a OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"},
+	}
+
+	for _, c := range cases {
+		sql, _, err := c.b.Dialect(c.d).ToSQL()
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, sql)
+	}
+}
+
+func TestCompoundPagination(t *testing.T) {
+	sql, _, err := Union(Select("a").From("t1"), Select("a").From("t2")).
+		OrderBy("a").
+		Limit(10).
+		Dialect(SQLServer).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM t1 UNION SELECT a FROM t2 ORDER BY a OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY", sql)
+}
+
+func TestModifyPagination(t *testing.T) {
+	sql, _, err := Update("t").Set("a", 1).Where("b = ?", 2).Limit(10).Dialect(SQLServer).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE TOP (10) t SET a = ? WHERE b = ?", sql)
+
+	sql, _, err = Delete("t").Where("b = ?", 2).Limit(10).Dialect(SQLServer).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE TOP (10) FROM t WHERE b = ?", sql)
+
+	sql, _, err = Delete("t").Where("b = ?", 2).OrderBy("id").Limit(10).Dialect(MySQL).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM t WHERE b = ? ORDER BY id LIMIT 10", sql)
+
+	_, _, err = Update("t").Set("a", 1).Limit(10).Dialect(Postgres).ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = Delete("t").Limit(10).Dialect(Oracle).ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = Update("t").Set("a", 1).Limit(10).Offset(5).Dialect(MySQL).ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = Delete("t").Limit(10).Offset(5).Dialect(SQLServer).ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = Update("t").Set("a", 1).OrderBy("c").Limit(2).Dialect(SQLServer).ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = Delete("t").OrderBy("c").Limit(2).Dialect(SQLServer).ToSQL()
+	assert.Error(t, err)
+}
@@ -10,24 +10,27 @@ type part struct {
 	args []interface{}
 }
 
-func newPart(pred interface{}, args ...interface{}) sqlWriter {
+func newPart(pred interface{}, args ...interface{}) Sqlizer {
 	return &part{pred, args}
 }
 
-func (p part) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
+func (p part) WriteSQL(b *bytes.Buffer) (args []interface{}, err error) {
+	return p.writeDialectSQL(b, dialectOptions{})
+}
+
+func (p part) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
 	switch pred := p.pred.(type) {
-	case sqlWriter:
-		args, err = pred.toSQL(b)
+	case Sqlizer:
+		args, err = writeSQL(b, pred, d)
 	case string:
-		b.WriteString(pred)
-		args = p.args
+		args, err = Expr(pred, p.args...).writeDialectSQL(b, d)
 	default:
 		err = fmt.Errorf("expected string or Sqlizer, not %T", pred)
 	}
 	return
 }
 
-func appendToSQL(parts []sqlWriter, b *bytes.Buffer, sep string, args []interface{}) ([]interface{}, error) {
+func appendToSQL(parts []Sqlizer, b *bytes.Buffer, sep string, d dialectOptions, args []interface{}) ([]interface{}, error) {
 	for i, p := range parts {
 		if i > 0 {
 			if _, err := b.WriteString(sep); err != nil {
@@ -35,7 +38,7 @@ func appendToSQL(parts []sqlWriter, b *bytes.Buffer, sep string, args []interfac
 			}
 		}
 
-		partArgs, err := p.toSQL(b)
+		partArgs, err := writeSQL(b, p, d)
 		if err != nil {
 			return nil, err
 		}
@@ -22,6 +22,16 @@ var (
 	// Dollar is a PlaceholderFormat instance that replaces placeholders with
 	// dollar-prefixed positional placeholders (e.g. $1, $2, $3).
 	Dollar = dollarFormat{}
+
+	// Colon is a PlaceholderFormat instance that replaces placeholders with
+	// colon-prefixed positional placeholders (e.g. :1, :2, :3), as used by
+	// Oracle.
+	Colon = colonFormat{}
+
+	// AtP is a PlaceholderFormat instance that replaces placeholders with
+	// "@p"-prefixed positional placeholders (e.g. @p1, @p2, @p3), as used by
+	// SQL Server.
+	AtP = atpFormat{}
 )
 
 type questionFormat struct{}
@@ -39,6 +49,24 @@ func (q dollarFormat) ReplacePlaceholders(sql string) (string, error) {
 	})
 }
 
+type colonFormat struct{}
+
+func (q colonFormat) ReplacePlaceholders(sql string) (string, error) {
+	return replacePlaceholders(sql, func(buf *bytes.Buffer, i int) error {
+		fmt.Fprintf(buf, ":%d", i)
+		return nil
+	})
+}
+
+type atpFormat struct{}
+
+func (q atpFormat) ReplacePlaceholders(sql string) (string, error) {
+	return replacePlaceholders(sql, func(buf *bytes.Buffer, i int) error {
+		fmt.Fprintf(buf, "@p%d", i)
+		return nil
+	})
+}
+
 // Placeholders returns a string with count ? placeholders joined with commas.
 func Placeholders(count int) string {
 	if count < 1 {
@@ -48,32 +76,154 @@ func Placeholders(count int) string {
 	return strings.Repeat(",?", count)[1:]
 }
 
+// replacePlaceholders calls replace for each question mark placeholder in sql
+// and unescapes "??" to "?".
+//
+// See scanPlaceholders for the rules of placeholder detection.
 func replacePlaceholders(sql string, replace func(buf *bytes.Buffer, i int) error) (string, error) {
-	buf := &bytes.Buffer{}
-	i := 0
-	for {
-		p := strings.Index(sql, "?")
-		if p == -1 {
-			break
+	return scanPlaceholders(sql, true, Generic, replace)
+}
+
+// scanPlaceholders calls replace for each question mark placeholder in sql.
+// Question marks are not placeholders when they appear in
+//
+//   - string literals: 'what?', E'what\'s?' and $tag$what?$tag$, and
+//     'what\'s?' and "what\"s?" for MySQL dialect d
+//   - quoted identifiers: "what?" and `what?`
+//   - comments: -- what? and /* what? */
+//   - PostgreSQL JSONB operators ?| and ?&
+//   - escape sequence ??, which is written as ? if unescape is true
+func scanPlaceholders(sql string, unescape bool, d Dialect, replace func(buf *bytes.Buffer, i int) error) (string, error) {
+	n := 0
+	return lexSQL(sql, d, func(buf *bytes.Buffer, p int) (int, error) {
+		if sql[p] != '?' {
+			return p, nil
 		}
 
-		if len(sql[p:]) > 1 && sql[p:p+2] == "??" { // escape ?? => ?
-			buf.WriteString(sql[:p])
-			buf.WriteString("?")
-			if len(sql[p:]) == 1 {
-				break
+		switch {
+		case strings.HasPrefix(sql[p:], "??"):
+			if unescape {
+				buf.WriteByte('?')
+			} else {
+				buf.WriteString("??")
+			}
+			return p + 2, nil
+		case strings.HasPrefix(sql[p:], "?&"),
+			strings.HasPrefix(sql[p:], "?|") && !strings.HasPrefix(sql[p:], "?||"):
+			buf.WriteString(sql[p : p+2])
+			return p + 2, nil
+		}
+
+		n++
+		return p + 1, replace(buf, n)
+	})
+}
+
+// lexSQL copies sql to a new string, skipping over string literals, quoted
+// identifiers and comments. For every other byte visit is called, it either
+// writes a replacement to buf and returns the position to continue from, or
+// returns p to copy the byte as is. MySQL dialect d escapes quotes with
+// backslashes in strings.
+func lexSQL(sql string, d Dialect, visit func(buf *bytes.Buffer, p int) (int, error)) (string, error) {
+	buf := &bytes.Buffer{}
+	buf.Grow(len(sql))
+
+	for p := 0; p < len(sql); {
+		c := sql[p]
+
+		var end int
+		switch {
+		case c == '\'', c == '"':
+			end = skipQuoted(sql, p, c, d == MySQL)
+		case c == '`':
+			end = skipQuoted(sql, p, c, false)
+		case (c == 'E' || c == 'e') && strings.HasPrefix(sql[p+1:], "'") && (p == 0 || !isIdentByte(sql[p-1])):
+			end = skipQuoted(sql, p+1, '\'', true)
+		case c == '-' && strings.HasPrefix(sql[p:], "--"):
+			end = strings.IndexByte(sql[p:], '\n')
+			if end == -1 {
+				end = len(sql)
+			} else {
+				end += p + 1
+			}
+		case c == '/' && strings.HasPrefix(sql[p:], "/*"):
+			end = strings.Index(sql[p+2:], "*/")
+			if end == -1 {
+				end = len(sql)
+			} else {
+				end += p + 4
 			}
-			sql = sql[p+2:]
-		} else {
-			i++
-			buf.WriteString(sql[:p])
-			if err := replace(buf, i); err != nil {
+		case c == '$' && (p == 0 || !isIdentByte(sql[p-1])):
+			end = skipDollarQuoted(sql, p)
+		default:
+			next, err := visit(buf, p)
+			if err != nil {
 				return "", err
 			}
-			sql = sql[p+1:]
+			if next > p {
+				p = next
+				continue
+			}
+		}
+
+		if end <= p {
+			end = p + 1
 		}
+
+		buf.WriteString(sql[p:end])
+		p = end
 	}
 
-	buf.WriteString(sql)
 	return buf.String(), nil
 }
+
+// skipQuoted returns the position after the closing quote of a literal
+// starting at sql[start]. Doubled quote is an escaped quote, backslash escapes
+// are recognized only if backslash is true.
+func skipQuoted(sql string, start int, quote byte, backslash bool) int {
+	for p := start + 1; p < len(sql); p++ {
+		switch sql[p] {
+		case '\\':
+			if backslash {
+				p++
+			}
+		case quote:
+			if p+1 < len(sql) && sql[p+1] == quote {
+				p++
+				continue
+			}
+			return p + 1
+		}
+	}
+	return len(sql)
+}
+
+// skipDollarQuoted returns the position after PostgreSQL dollar-quoted string
+// starting at sql[start], e.g. $$text$$ or $tag$text$tag$. If there is no
+// dollar-quoted string at start, e.g. it is a positional parameter $1, start
+// is returned.
+func skipDollarQuoted(sql string, start int) int {
+	p := start + 1
+	for p < len(sql) && isIdentByte(sql[p]) {
+		if p == start+1 && sql[p] >= '0' && sql[p] <= '9' {
+			return start
+		}
+		p++
+	}
+
+	if p >= len(sql) || sql[p] != '$' {
+		return start
+	}
+
+	tag := sql[start : p+1]
+	end := strings.Index(sql[p+1:], tag)
+	if end == -1 {
+		return len(sql)
+	}
+
+	return p + 1 + end + len(tag)
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
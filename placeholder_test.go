@@ -18,6 +18,42 @@ func TestDollar(t *testing.T) {
 	assert.Equal(t, "x = $1 AND y = $2", s)
 }
 
+func TestColon(t *testing.T) {
+	sql := "x = ? AND y = ?"
+	s, _ := Colon.ReplacePlaceholders(sql)
+	assert.Equal(t, "x = :1 AND y = :2", s)
+}
+
+func TestAtP(t *testing.T) {
+	sql := "x = ? AND y = ??"
+	s, _ := AtP.ReplacePlaceholders(sql)
+	assert.Equal(t, "x = @p1 AND y = ?", s)
+}
+
+func TestPlaceholderFormatBuilders(t *testing.T) {
+	sb := StatementBuilder.PlaceholderFormat(AtP)
+
+	sql, _, err := sb.Select("a").From("b").Where("c = ? AND d = ?", 1, 2).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b WHERE c = @p1 AND d = @p2", sql)
+
+	sql, _, err = sb.Insert("a").Values(1, 2).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a VALUES (@p1,@p2)", sql)
+
+	sql, _, err = Update("a").Set("b", 1).Where("c = ?", 2).PlaceholderFormat(Colon).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE a SET b = :1 WHERE c = :2", sql)
+
+	sql, _, err = Delete("a").Where("b = ?", 1).PlaceholderFormat(Colon).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM a WHERE b = :1", sql)
+
+	sql, _, err = Union(Select("a").Where("b = ?", 1), Select("a").Where("b = ?", 2)).PlaceholderFormat(Colon).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a WHERE b = :1 UNION SELECT a WHERE b = :2", sql)
+}
+
 func TestPlaceholders(t *testing.T) {
 	assert.Equal(t, Placeholders(2), "?,?")
 }
@@ -25,7 +61,57 @@ func TestPlaceholders(t *testing.T) {
 func TestEscape(t *testing.T) {
 	sql := "SELECT uuid, \"data\" #> '{tags}' AS tags FROM nodes WHERE  \"data\" -> 'tags' ??| array['?'] AND enabled = ?"
 	s, _ := Dollar.ReplacePlaceholders(sql)
-	assert.Equal(t, "SELECT uuid, \"data\" #> '{tags}' AS tags FROM nodes WHERE  \"data\" -> 'tags' ?| array['$1'] AND enabled = $2", s)
+	assert.Equal(t, "SELECT uuid, \"data\" #> '{tags}' AS tags FROM nodes WHERE  \"data\" -> 'tags' ?| array['?'] AND enabled = $1", s)
+}
+
+func TestReplacePlaceholdersSkipsLiterals(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected string
+	}{
+		{"a = 'what?' AND b = ?", "a = 'what?' AND b = $1"},
+		{"a = 'it''s?' AND b = ?", "a = 'it''s?' AND b = $1"},
+		{"a = E'it\\'s?' AND b = ?", "a = E'it\\'s?' AND b = $1"},
+		{"a = 'C:\\' AND b = ?", "a = 'C:\\' AND b = $1"},
+		{"\"what?\" = ? AND `why?` = ?", "\"what?\" = $1 AND `why?` = $2"},
+		{"a = ? -- why?\nAND b = ?", "a = $1 -- why?\nAND b = $2"},
+		{"a = ? /* why? */ AND b = ?", "a = $1 /* why? */ AND b = $2"},
+		{"a = $$why?$$ AND b = $x$?$x$ AND c = ?", "a = $$why?$$ AND b = $x$?$x$ AND c = $1"},
+		{"a ?| array[?] AND b ?& array[?] AND c ?? 'k'", "a ?| array[$1] AND b ?& array[$2] AND c ? 'k'"},
+		{"a = ?||'x'", "a = $1||'x'"},
+		{"a = 'unterminated ?", "a = 'unterminated ?"},
+		{"a = ? -- trailing?", "a = $1 -- trailing?"},
+		{"a = ? /* unterminated?", "a = $1 /* unterminated?"},
+	}
+
+	for _, test := range tests {
+		s, err := Dollar.ReplacePlaceholders(test.sql)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, s, test.sql)
+	}
+}
+
+func TestExprKeepsEscapedPlaceholders(t *testing.T) {
+	sql, args, err := Select("a").
+		Where(Expr("b ?? 'k' AND c = ? AND d = '?' AND e IN (?)", 1, Select("f").From("g").Where("h = ?", 2))).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a WHERE b ? 'k' AND c = $1 AND d = '?' AND e IN (SELECT f FROM g WHERE h = $2)", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+}
+
+func TestExprMySQLBackslashEscapes(t *testing.T) {
+	sql, args, err := Select("a").
+		Where(Expr("b = 'it\\'s ?' AND c IN (?)", Select("d").From("e").Where("f = ?", 1))).
+		Where("g = \"\\\" :x\" AND h = :h", Params{"h": 2}).
+		Dialect(MySQL).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a WHERE b = 'it\\'s ?' AND c IN (SELECT d FROM e WHERE f = ?) AND g = \"\\\" :x\" AND h = ?", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
 }
 
 func BenchmarkPlaceholdersStrings(b *testing.B) {
@@ -0,0 +1,277 @@
+package sqrl
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Between is syntactic sugar for use with Where/Having methods. Values are
+// pairs of inclusive bounds.
+// Ex:
+//
+//	.Where(Between{"age": {18, 65}}) == "age BETWEEN ? AND ?"
+type Between map[string][2]interface{}
+
+// WriteSQL implements Sqlizer.
+func (bt Between) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return bt.writeDialectSQL(b, dialectOptions{})
+}
+
+func (bt Between) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return betweenToSQL(bt, b, d, false)
+}
+
+// NotBetween is the negation of Between.
+// Ex:
+//
+//	.Where(NotBetween{"age": {18, 65}}) == "age NOT BETWEEN ? AND ?"
+type NotBetween map[string][2]interface{}
+
+// WriteSQL implements Sqlizer.
+func (nbt NotBetween) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return nbt.writeDialectSQL(b, dialectOptions{})
+}
+
+func (nbt NotBetween) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return betweenToSQL(nbt, b, d, true)
+}
+
+func betweenToSQL(m map[string][2]interface{}, b *bytes.Buffer, d dialectOptions, useNotOpr bool) (args []interface{}, err error) {
+	opr := " BETWEEN "
+	if useNotOpr {
+		opr = " NOT BETWEEN "
+	}
+
+	bounds := make(map[string]interface{}, len(m))
+	for column, bound := range m {
+		bounds[column] = bound
+	}
+
+	for i, p := range sortedPairs(bounds) {
+		if i > 0 {
+			b.WriteString(" AND ")
+		}
+
+		b.WriteString(p.Column + opr)
+
+		for j, bound := range p.Value.([2]interface{}) {
+			if j > 0 {
+				b.WriteString(" AND ")
+			}
+
+			sql, boundArgs, err := operandValueToSQL(bound, d, "BETWEEN")
+			if err != nil {
+				return nil, err
+			}
+
+			b.WriteString(sql)
+			args = append(args, boundArgs...)
+		}
+	}
+
+	return
+}
+
+type notExpr struct {
+	pred Sqlizer
+}
+
+// Not negates a predicate: "NOT (pred)". The pred and args are handled the
+// same way as in SelectBuilder.Where.
+// Ex:
+//
+//	.Where(Not(Or{Eq{"a": 1}, Eq{"b": 2}})) == "NOT ((a = ? OR b = ?))"
+func Not(pred interface{}, args ...interface{}) Sqlizer {
+	return notExpr{pred: newWherePart(pred, args...)}
+}
+
+// WriteSQL implements Sqlizer.
+func (e notExpr) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return e.writeDialectSQL(b, dialectOptions{})
+}
+
+func (e notExpr) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	if e.pred == nil {
+		return nil, errors.New("not expression must have a predicate")
+	}
+
+	b.WriteString("NOT (")
+
+	args, err = appendToSQL([]Sqlizer{e.pred}, b, "", d, nil)
+	if err != nil {
+		return
+	}
+
+	b.WriteByte(')')
+
+	return
+}
+
+// IsNotDistinctFrom is a null-safe Eq: NULL values are equal to each other.
+// It is rendered as "a IS NOT DISTINCT FROM ?", as "a <=> ?" for MySQL, as
+// "a IS ?" for SQLite and with DECODE for Oracle.
+// Ex:
+//
+//	.Where(IsNotDistinctFrom{"parent_id": parentID})
+type IsNotDistinctFrom map[string]interface{}
+
+// WriteSQL implements Sqlizer.
+func (nd IsNotDistinctFrom) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return nd.writeDialectSQL(b, dialectOptions{})
+}
+
+func (nd IsNotDistinctFrom) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return distinctToSQL(sortedPairs(nd), b, d, false)
+}
+
+// IsDistinctFrom is a null-safe Neq. See IsNotDistinctFrom.
+// Ex:
+//
+//	.Where(IsDistinctFrom{"status": prev}) == "status IS DISTINCT FROM ?"
+type IsDistinctFrom map[string]interface{}
+
+// WriteSQL implements Sqlizer.
+func (dt IsDistinctFrom) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return dt.writeDialectSQL(b, dialectOptions{})
+}
+
+func (dt IsDistinctFrom) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return distinctToSQL(sortedPairs(dt), b, d, true)
+}
+
+func distinctToSQL(pairs []Pair, b *bytes.Buffer, d dialectOptions, distinct bool) (args []interface{}, err error) {
+	for i, p := range pairs {
+		if i > 0 {
+			b.WriteString(" AND ")
+		}
+
+		key, val := p.Column, p.Value
+
+		switch v := val.(type) {
+		case driver.Valuer:
+			if val, err = v.Value(); err != nil {
+				return
+			}
+		}
+
+		if val == nil {
+			if distinct {
+				b.WriteString(key + " IS NOT NULL")
+			} else {
+				b.WriteString(key + " IS NULL")
+			}
+			continue
+		}
+
+		valVal := reflect.ValueOf(val)
+		if valVal.Kind() == reflect.Array || valVal.Kind() == reflect.Slice {
+			err = errors.New("cannot use array or slice with distinct from operators")
+			return
+		}
+
+		switch {
+		case d.Dialect == MySQL && distinct:
+			b.WriteString("NOT " + key + " <=> ?")
+		case d.Dialect == MySQL:
+			b.WriteString(key + " <=> ?")
+		case d.Dialect == SQLite && distinct:
+			b.WriteString(key + " IS NOT ?")
+		case d.Dialect == SQLite:
+			b.WriteString(key + " IS ?")
+		case d.Dialect == Oracle && distinct:
+			b.WriteString("DECODE(" + key + ", ?, 0, 1) = 1")
+		case d.Dialect == Oracle:
+			b.WriteString("DECODE(" + key + ", ?, 0, 1) = 0")
+		case distinct:
+			b.WriteString(key + " IS DISTINCT FROM ?")
+		default:
+			b.WriteString(key + " IS NOT DISTINCT FROM ?")
+		}
+
+		args = append(args, val)
+	}
+
+	return
+}
+
+// Op is a comparison of a column and a value with any binary operator, e.g.
+// Postgres "@>", "&&" or "~". The value can be a Sqlizer, which is rendered
+// in place of the placeholder, or a subquery enclosed in parentheses. A
+// question mark in the operator must be escaped as "??".
+//
+// Like Eq, "=" and "<>" with a nil value are rendered as "IS [NOT] NULL" and
+// with a slice as "[NOT] IN (...)". Other operators don't accept nil or
+// slice values, which should be converted to driver values, e.g. with
+// pq.Array.
+// Ex:
+//
+//	.Where(Op{"tags", "&&", pq.Array(tags)}) == "tags && ?"
+type Op struct {
+	Column   string
+	Operator string
+	Value    interface{}
+}
+
+// WriteSQL implements Sqlizer.
+func (op Op) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return op.writeDialectSQL(b, dialectOptions{})
+}
+
+func (op Op) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	switch op.Operator {
+	case "=":
+		return equalityToSQL([]Pair{{op.Column, op.Value}}, b, d, false)
+	case "<>", "!=":
+		return equalityToSQL([]Pair{{op.Column, op.Value}}, b, d, true)
+	}
+
+	sql, args, err := operandValueToSQL(op.Value, d, op.Operator)
+	if err != nil {
+		return nil, err
+	}
+
+	b.WriteString(op.Column + " " + op.Operator + " " + sql)
+
+	return args, nil
+}
+
+// operandValueToSQL renders a single value operand of operator: a Sqlizer in
+// place, a subquery in parentheses or a placeholder bound to the value. Nil
+// and slice values are errors.
+func operandValueToSQL(val interface{}, d dialectOptions, operator string) (sql string, args []interface{}, err error) {
+	if s, ok := val.(Sqlizer); ok {
+		var subquery bool
+		if sql, args, subquery, err = operandToSQL(s, d); err != nil {
+			return
+		}
+
+		if subquery {
+			sql = "(" + sql + ")"
+		}
+
+		return
+	}
+
+	if v, ok := val.(driver.Valuer); ok {
+		if val, err = v.Value(); err != nil {
+			return
+		}
+	}
+
+	if val == nil {
+		err = fmt.Errorf("cannot use null with %s operator", operator)
+		return
+	}
+
+	// []byte is a single value, e.g. a JSON document
+	valVal := reflect.ValueOf(val)
+	if _, ok := val.([]byte); !ok && (valVal.Kind() == reflect.Array || valVal.Kind() == reflect.Slice) {
+		err = fmt.Errorf("cannot use array or slice with %s operator", operator)
+		return
+	}
+
+	return "?", []interface{}{val}, nil
+}
@@ -0,0 +1,116 @@
+package sqrl
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBetween(t *testing.T) {
+	b := &bytes.Buffer{}
+	args, err := Between{"b": {1, 2}, "a": {"x", "y"}}.WriteSQL(b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a BETWEEN ? AND ? AND b BETWEEN ? AND ?", b.String())
+	assert.Equal(t, []interface{}{"x", "y", 1, 2}, args)
+
+	b.Reset()
+	args, err = NotBetween{"a": {1, 2}}.WriteSQL(b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a NOT BETWEEN ? AND ?", b.String())
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	_, err = Between{"a": {1, nil}}.WriteSQL(b)
+	assert.Error(t, err)
+
+	_, err = Between{"a": {sql.NullInt64{}, 2}}.WriteSQL(b)
+	assert.Error(t, err)
+
+	_, err = Between{"a": {[]int{1}, 2}}.WriteSQL(b)
+	assert.Error(t, err)
+
+	b.Reset()
+	args, err = Between{"a": {sql.NullInt64{Int64: 1, Valid: true}, Expr("NOW() - ?", "1 day")}}.WriteSQL(b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a BETWEEN ? AND NOW() - ?", b.String())
+	assert.Equal(t, []interface{}{int64(1), "1 day"}, args)
+
+	s, args, err := Select("a").
+		From("t").
+		Where(Between{"b": {Select("MIN(b)").From("u").Where("c = ?", 1), 5}}).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM t WHERE b BETWEEN (SELECT MIN(b) FROM u WHERE c = ?) AND ?", s)
+	assert.Equal(t, []interface{}{1, 5}, args)
+}
+
+func TestNot(t *testing.T) {
+	sql, args, err := Select("a").
+		From("t").
+		Where(Not(Or{Eq{"b": 1}, Lt{"c": 2}})).
+		Where(Not("d = ?", 3)).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM t WHERE NOT ((b = ? OR c < ?)) AND NOT (d = ?)", sql)
+	assert.Equal(t, []interface{}{1, 2, 3}, args)
+
+	_, err = Not(nil).WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestDistinctFrom(t *testing.T) {
+	cases := []struct {
+		d        Dialect
+		pred     Sqlizer
+		expected string
+	}{
+		{Postgres, IsNotDistinctFrom{"a": 1}, "a IS NOT DISTINCT FROM ?"},
+		{Postgres, IsDistinctFrom{"a": 1}, "a IS DISTINCT FROM ?"},
+		{MySQL, IsNotDistinctFrom{"a": 1}, "a <=> ?"},
+		{MySQL, IsDistinctFrom{"a": 1}, "NOT a <=> ?"},
+		{SQLite, IsNotDistinctFrom{"a": 1}, "a IS ?"},
+		{SQLite, IsDistinctFrom{"a": 1}, "a IS NOT ?"},
+		{Oracle, IsNotDistinctFrom{"a": 1}, "DECODE(a, ?, 0, 1) = 0"},
+		{Oracle, IsDistinctFrom{"a": 1}, "DECODE(a, ?, 0, 1) = 1"},
+		{MySQL, IsNotDistinctFrom{"a": nil, "b": 1}, "a IS NULL AND b <=> ?"},
+		{MySQL, IsDistinctFrom{"a": nil}, "a IS NOT NULL"},
+	}
+
+	for _, c := range cases {
+		sql, _, err := Select("*").From("t").Where(c.pred).Dialect(c.d).ToSQL()
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM t WHERE "+c.expected, sql)
+	}
+
+	_, err := IsDistinctFrom{"a": []int{1}}.WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestOp(t *testing.T) {
+	sql, args, err := Select("*").
+		From("t").
+		Where(Op{"tags", "&&", "{a,b}"}).
+		Where(Op{"name", "~", "^a"}).
+		Where(Op{"id", "=", []int{1, 2}}).
+		Where(Op{"parent_id", "<>", nil}).
+		Where(Op{"doc", "@>", []byte(`{"a":1}`)}).
+		Where(Op{"ts", ">", Expr("NOW() - ?::interval", "1 day")}).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE tags && $1 AND name ~ $2 AND id IN ($3,$4) AND parent_id IS NOT NULL AND doc @> $5 AND ts > NOW() - $6::interval", sql)
+	assert.Equal(t, []interface{}{"{a,b}", "^a", 1, 2, []byte(`{"a":1}`), "1 day"}, args)
+
+	_, err = Op{"a", "@>", nil}.WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+
+	_, err = Op{"a", "@>", []string{"x"}}.WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+}
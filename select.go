@@ -5,28 +5,59 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"strconv"
 	"strings"
 )
 
+// derivedTable is a subquery used as a table: "(query) AS alias(columns)"
+type derivedTable struct {
+	query   Sqlizer
+	alias   string
+	columns []string
+}
+
+func (t derivedTable) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return t.writeDialectSQL(b, dialectOptions{})
+}
+
+func (t derivedTable) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	b.WriteByte('(')
+
+	args, err := writeSQL(b, t.query, d)
+	if err != nil {
+		return nil, err
+	}
+
+	b.WriteString(") AS " + t.alias)
+
+	if len(t.columns) > 0 {
+		b.WriteString("(" + strings.Join(t.columns, ", ") + ")")
+	}
+
+	return args, nil
+}
+
+type namedWindow struct {
+	name   string
+	window *WindowBuilder
+}
+
 // SelectBuilder builds SQL SELECT statements.
 type SelectBuilder struct {
 	StatementBuilderType
 
+	ctes        []cte
 	prefixes    []expr
 	distinct    bool
-	columns     []sqlWriter
-	from        string
-	joins       []string
-	whereParts  []sqlWriter
+	columns     []Sqlizer
+	from        Sqlizer
+	joins       []Sqlizer
+	whereParts  []Sqlizer
 	groupBys    []string
-	havingParts []sqlWriter
-	orderBys    []string
+	havingParts []Sqlizer
+	windows     []namedWindow
+	orderBys    []Sqlizer
 
-	limit       uint64
-	limitValid  bool
-	offset      uint64
-	offsetValid bool
+	pagination
 
 	suffixes []expr
 }
@@ -96,28 +127,48 @@ func (b *SelectBuilder) PlaceholderFormat(f PlaceholderFormat) *SelectBuilder {
 	return b
 }
 
+// Dialect sets Dialect (e.g. Postgres or SQLServer) for the query.
+func (b *SelectBuilder) Dialect(d Dialect) *SelectBuilder {
+	b.dialect = d
+	return b
+}
+
+// BindArrays sets whether slice values of Eq and Neq are bound as a single
+// array parameter, see Array.
+func (b *SelectBuilder) BindArrays(enabled bool) *SelectBuilder {
+	b.bindArrays = enabled
+	return b
+}
+
 // ToSQL builds the query into a SQL string and bound args.
 func (b *SelectBuilder) ToSQL() (sqlStr string, args []interface{}, err error) {
 	sql := bytes.NewBuffer(make([]byte, 0, 200))
-	args, err = b.toSQL(sql)
+	args, err = b.WriteSQL(sql)
 	if err != nil {
 		return
 	}
 
 	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
+	args = namedArgsLast(args)
 	return
 }
 
-//toSQL implements sqlWriter
+//WriteSQL implements Sqlizer
 //the SelectBuilder must implement this interface since it can be used within other queries
-func (b *SelectBuilder) toSQL(sql *bytes.Buffer) (args []interface{}, err error) {
+func (b *SelectBuilder) WriteSQL(sql *bytes.Buffer) (args []interface{}, err error) {
+	return b.writeDialectSQL(sql, dialectOptions{})
+}
+
+func (b *SelectBuilder) writeDialectSQL(sql *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	d = b.renderOptions(d)
+
 	if len(b.columns) == 0 {
 		err = errors.New("select statements must have at least one result column")
 		return
 	}
 
 	if len(b.prefixes) > 0 {
-		args, err = appendExpressionsToSQL(sql, b.prefixes, " ", args)
+		args, err = appendExpressionsToSQL(sql, b.prefixes, " ", d, args)
 		if err != nil {
 			return
 		}
@@ -125,30 +176,50 @@ func (b *SelectBuilder) toSQL(sql *bytes.Buffer) (args []interface{}, err error)
 		sql.WriteByte(' ')
 	}
 
+	if len(b.ctes) > 0 {
+		args, err = appendCTEsToSQL(sql, b.ctes, d, args)
+		if err != nil {
+			return
+		}
+	}
+
 	sql.WriteString("SELECT ")
 
 	if b.distinct {
 		sql.WriteString("DISTINCT ")
 	}
 
+	top := b.useTop(d.Dialect)
+	if top {
+		b.writeTop(sql)
+	}
+
 	if len(b.columns) > 0 {
-		args, err = appendToSQL(b.columns, sql, ", ", args)
+		args, err = appendToSQL(b.columns, sql, ", ", d, args)
 		if err != nil {
 			return
 		}
 	}
 
-	if len(b.from) > 0 {
-		sql.WriteString(" FROM " + b.from)
+	if b.from != nil {
+		sql.WriteString(" FROM ")
+		args, err = appendToSQL([]Sqlizer{b.from}, sql, "", d, args)
+		if err != nil {
+			return
+		}
 	}
 
 	if len(b.joins) > 0 {
-		sql.WriteString(" " + strings.Join(b.joins, " "))
+		sql.WriteByte(' ')
+		args, err = appendToSQL(b.joins, sql, " ", d, args)
+		if err != nil {
+			return
+		}
 	}
 
 	if len(b.whereParts) > 0 {
 		sql.WriteString(" WHERE ")
-		args, err = appendToSQL(b.whereParts, sql, " AND ", args)
+		args, err = appendToSQL(b.whereParts, sql, " AND ", d, args)
 		if err != nil {
 			return
 		}
@@ -160,28 +231,41 @@ func (b *SelectBuilder) toSQL(sql *bytes.Buffer) (args []interface{}, err error)
 
 	if len(b.havingParts) > 0 {
 		sql.WriteString(" HAVING ")
-		args, err = appendToSQL(b.havingParts, sql, " AND ", args)
+		args, err = appendToSQL(b.havingParts, sql, " AND ", d, args)
 		if err != nil {
 			return
 		}
 	}
 
-	if len(b.orderBys) > 0 {
-		sql.WriteString(" ORDER BY " + strings.Join(b.orderBys, ", "))
+	if len(b.windows) > 0 {
+		sql.WriteString(" WINDOW ")
+		for i, w := range b.windows {
+			if i > 0 {
+				sql.WriteString(", ")
+			}
+
+			sql.WriteString(w.name + " AS ")
+			args, err = appendToSQL([]Sqlizer{w.window}, sql, "", d, args)
+			if err != nil {
+				return
+			}
+		}
 	}
 
-	if b.limitValid {
-		sql.WriteString(" LIMIT " + strconv.FormatUint(b.limit, 10))
+	if len(b.orderBys) > 0 {
+		sql.WriteString(" ORDER BY ")
+		args, err = appendToSQL(b.orderBys, sql, ", ", d, args)
+		if err != nil {
+			return
+		}
 	}
 
-	if b.offsetValid {
-		sql.WriteString(" OFFSET " + strconv.FormatUint(b.offset, 10))
-	}
+	b.writeSelectSQL(sql, d.Dialect, len(b.orderBys) > 0, top)
 
 	if len(b.suffixes) > 0 {
 		sql.WriteByte(' ')
 
-		args, err = appendExpressionsToSQL(sql, b.suffixes, " ", args)
+		args, err = appendExpressionsToSQL(sql, b.suffixes, " ", d, args)
 		if err != nil {
 			return
 		}
@@ -191,6 +275,21 @@ func (b *SelectBuilder) toSQL(sql *bytes.Buffer) (args []interface{}, err error)
 
 }
 
+// With adds a named query to WITH clause of the query. The query can be
+// any Sqlizer, e.g. SelectBuilder or a data-modifying statement like
+// InsertBuilder. Its args precede the args of the main query.
+func (b *SelectBuilder) With(name string, query Sqlizer) *SelectBuilder {
+	b.ctes = append(b.ctes, cte{name: name, query: query})
+	return b
+}
+
+// WithRecursive adds a recursive named query with optional column names to
+// WITH clause of the query, usually a CompoundBuilder.
+func (b *SelectBuilder) WithRecursive(name string, columns []string, query Sqlizer) *SelectBuilder {
+	b.ctes = append(b.ctes, cte{name: name, columns: columns, recursive: true, query: query})
+	return b
+}
+
 // Prefix adds an expression to the beginning of the query
 func (b *SelectBuilder) Prefix(sql string, args ...interface{}) *SelectBuilder {
 	b.prefixes = append(b.prefixes, Expr(sql, args...))
@@ -231,30 +330,166 @@ func (b *SelectBuilder) Column(column interface{}, args ...interface{}) *SelectB
 
 // From sets the FROM clause of the query.
 func (b *SelectBuilder) From(from string) *SelectBuilder {
-	b.from = from
+	b.from = nil
+	if len(from) > 0 {
+		b.from = newPart(from)
+	}
+
+	return b
+}
+
+// FromSelect sets a subquery as the FROM clause of the query:
+// "FROM (SELECT ...) AS alias".
+func (b *SelectBuilder) FromSelect(from *SelectBuilder, alias string) *SelectBuilder {
+	b.from = derivedTable{query: from, alias: alias}
 	return b
 }
 
-// JoinClause adds a join clause to the query.
-func (b *SelectBuilder) JoinClause(join string) *SelectBuilder {
-	b.joins = append(b.joins, join)
+// FromValues sets a VALUES list with optional column names as the FROM
+// clause of the query: "FROM (VALUES (?,?),(?,?)) AS alias(columns)".
+func (b *SelectBuilder) FromValues(values *ValuesBuilder, alias string, columns ...string) *SelectBuilder {
+	b.from = derivedTable{query: values, alias: alias, columns: columns}
+	return b
+}
+
+// JoinClause adds a join clause to the query. The clause is either a string
+// with optional args bound to its placeholders, or a Sqlizer.
+func (b *SelectBuilder) JoinClause(join interface{}, args ...interface{}) *SelectBuilder {
+	if join != nil {
+		b.joins = append(b.joins, newPart(join, args...))
+	}
 
 	return b
 }
 
 // Join adds a JOIN clause to the query.
-func (b *SelectBuilder) Join(join string) *SelectBuilder {
-	return b.JoinClause("JOIN " + join)
+func (b *SelectBuilder) Join(join string, args ...interface{}) *SelectBuilder {
+	return b.JoinClause("JOIN "+join, args...)
 }
 
 // LeftJoin adds a LEFT JOIN clause to the query.
-func (b *SelectBuilder) LeftJoin(join string) *SelectBuilder {
-	return b.JoinClause("LEFT JOIN " + join)
+func (b *SelectBuilder) LeftJoin(join string, args ...interface{}) *SelectBuilder {
+	return b.JoinClause("LEFT JOIN "+join, args...)
 }
 
 // RightJoin adds a RIGHT JOIN clause to the query.
-func (b *SelectBuilder) RightJoin(join string) *SelectBuilder {
-	return b.JoinClause("RIGHT JOIN " + join)
+func (b *SelectBuilder) RightJoin(join string, args ...interface{}) *SelectBuilder {
+	return b.JoinClause("RIGHT JOIN "+join, args...)
+}
+
+// JoinOn adds a "JOIN table ON on" clause to the query. The table is either a
+// string or a Sqlizer, the on condition accepts the same types as Where.
+//
+// Ex:
+//
+//	.JoinOn("emails e", And{Expr("e.user_id = u.id"), Eq{"e.primary": true}})
+func (b *SelectBuilder) JoinOn(table interface{}, on interface{}, args ...interface{}) *SelectBuilder {
+	b.joins = append(b.joins, newJoinPart("JOIN", table, on, args...))
+	return b
+}
+
+// LeftJoinOn adds a "LEFT JOIN table ON on" clause to the query.
+//
+// See JoinOn.
+func (b *SelectBuilder) LeftJoinOn(table interface{}, on interface{}, args ...interface{}) *SelectBuilder {
+	b.joins = append(b.joins, newJoinPart("LEFT JOIN", table, on, args...))
+	return b
+}
+
+// RightJoinOn adds a "RIGHT JOIN table ON on" clause to the query.
+//
+// See JoinOn.
+func (b *SelectBuilder) RightJoinOn(table interface{}, on interface{}, args ...interface{}) *SelectBuilder {
+	b.joins = append(b.joins, newJoinPart("RIGHT JOIN", table, on, args...))
+	return b
+}
+
+// JoinSelect adds a "JOIN (SELECT ...) AS alias ON on" clause to the query.
+//
+// See JoinOn.
+func (b *SelectBuilder) JoinSelect(join *SelectBuilder, alias string, on interface{}, args ...interface{}) *SelectBuilder {
+	return b.JoinOn(derivedTable{query: join, alias: alias}, on, args...)
+}
+
+// LeftJoinSelect adds a "LEFT JOIN (SELECT ...) AS alias ON on" clause to the query.
+//
+// See JoinOn.
+func (b *SelectBuilder) LeftJoinSelect(join *SelectBuilder, alias string, on interface{}, args ...interface{}) *SelectBuilder {
+	return b.LeftJoinOn(derivedTable{query: join, alias: alias}, on, args...)
+}
+
+// RightJoinSelect adds a "RIGHT JOIN (SELECT ...) AS alias ON on" clause to the query.
+//
+// See JoinOn.
+func (b *SelectBuilder) RightJoinSelect(join *SelectBuilder, alias string, on interface{}, args ...interface{}) *SelectBuilder {
+	return b.RightJoinOn(derivedTable{query: join, alias: alias}, on, args...)
+}
+
+// FullJoin adds a FULL OUTER JOIN clause to the query.
+func (b *SelectBuilder) FullJoin(join string, args ...interface{}) *SelectBuilder {
+	return b.JoinClause("FULL OUTER JOIN "+join, args...)
+}
+
+// FullJoinOn adds a "FULL OUTER JOIN table ON on" clause to the query.
+//
+// See JoinOn.
+func (b *SelectBuilder) FullJoinOn(table interface{}, on interface{}, args ...interface{}) *SelectBuilder {
+	b.joins = append(b.joins, newJoinPart("FULL OUTER JOIN", table, on, args...))
+	return b
+}
+
+// FullJoinSelect adds a "FULL OUTER JOIN (SELECT ...) AS alias ON on" clause to the query.
+//
+// See JoinOn.
+func (b *SelectBuilder) FullJoinSelect(join *SelectBuilder, alias string, on interface{}, args ...interface{}) *SelectBuilder {
+	return b.FullJoinOn(derivedTable{query: join, alias: alias}, on, args...)
+}
+
+// CrossJoin adds a CROSS JOIN clause to the query.
+func (b *SelectBuilder) CrossJoin(join string, args ...interface{}) *SelectBuilder {
+	return b.JoinClause("CROSS JOIN "+join, args...)
+}
+
+// CrossJoinSelect adds a "CROSS JOIN (SELECT ...) AS alias" clause to the query.
+func (b *SelectBuilder) CrossJoinSelect(join *SelectBuilder, alias string) *SelectBuilder {
+	return b.JoinClause(newJoinPart("CROSS JOIN", derivedTable{query: join, alias: alias}, nil))
+}
+
+// NaturalJoin adds a NATURAL JOIN clause to the query.
+func (b *SelectBuilder) NaturalJoin(join string, args ...interface{}) *SelectBuilder {
+	return b.JoinClause("NATURAL JOIN "+join, args...)
+}
+
+// JoinLateral adds a "JOIN LATERAL (SELECT ...) AS alias ON on" clause to the
+// query. The subquery may refer to columns of preceding FROM items, its args
+// are bound in place. If on is nil the condition is "ON TRUE".
+func (b *SelectBuilder) JoinLateral(join *SelectBuilder, alias string, on interface{}, args ...interface{}) *SelectBuilder {
+	return b.lateralJoin("JOIN LATERAL", join, alias, on, args...)
+}
+
+// LeftJoinLateral adds a "LEFT JOIN LATERAL (SELECT ...) AS alias ON on" clause
+// to the query. If on is nil the condition is "ON TRUE".
+//
+// See JoinLateral.
+func (b *SelectBuilder) LeftJoinLateral(join *SelectBuilder, alias string, on interface{}, args ...interface{}) *SelectBuilder {
+	return b.lateralJoin("LEFT JOIN LATERAL", join, alias, on, args...)
+}
+
+// CrossJoinLateral adds a "CROSS JOIN LATERAL (SELECT ...) AS alias" clause
+// to the query.
+//
+// See JoinLateral.
+func (b *SelectBuilder) CrossJoinLateral(join *SelectBuilder, alias string) *SelectBuilder {
+	return b.JoinClause(newJoinPart("CROSS JOIN LATERAL", derivedTable{query: join, alias: alias}, nil))
+}
+
+func (b *SelectBuilder) lateralJoin(kind string, join *SelectBuilder, alias string, on interface{}, args ...interface{}) *SelectBuilder {
+	if on == nil {
+		on = "TRUE"
+	}
+
+	b.joins = append(b.joins, newJoinPart(kind, derivedTable{query: join, alias: alias}, on, args...))
+	return b
 }
 
 // Where adds an expression to the WHERE clause of the query.
@@ -274,7 +509,7 @@ func (b *SelectBuilder) RightJoin(join string) *SelectBuilder {
 // bound to the placeholder. If the value is nil, the expression will be "<key>
 // IS NULL". If the value is an array or slice, the expression will be "<key> IN
 // (?,?,...)", with one placeholder for each item in the value. These expressions
-// are ANDed together.
+// are ANDed together in the order of sorted keys.
 //
 // Where will panic if pred isn't any of the above types.
 func (b *SelectBuilder) Where(pred interface{}, args ...interface{}) *SelectBuilder {
@@ -296,13 +531,37 @@ func (b *SelectBuilder) Having(pred interface{}, rest ...interface{}) *SelectBui
 	return b
 }
 
+// Window adds a named window definition to WINDOW clause of the query:
+// "WINDOW name AS (...)". The window can be referred by name in Over.
+func (b *SelectBuilder) Window(name string, w *WindowBuilder) *SelectBuilder {
+	b.windows = append(b.windows, namedWindow{name: name, window: w})
+	return b
+}
+
 // OrderBy adds ORDER BY expressions to the query.
 func (b *SelectBuilder) OrderBy(orderBys ...string) *SelectBuilder {
-	b.orderBys = append(b.orderBys, orderBys...)
+	for _, str := range orderBys {
+		b.orderBys = append(b.orderBys, newPart(str))
+	}
+
+	return b
+}
+
+// OrderByClause adds ORDER BY expression to the query. Unlike OrderBy, it
+// accepts a Sqlizer or args which will be bound to placeholders in the
+// expression string, for example:
+//   OrderByClause(Over("RANK()", Window().OrderBy("score")))
+func (b *SelectBuilder) OrderByClause(pred interface{}, args ...interface{}) *SelectBuilder {
+	if pred != nil {
+		b.orderBys = append(b.orderBys, newPart(pred, args...))
+	}
+
 	return b
 }
 
-// Limit sets a LIMIT clause on the query.
+// Limit sets a LIMIT clause on the query. SQLServer dialect renders it as
+// "TOP (n)" unless Offset is set too, then both SQLServer and Oracle render
+// "OFFSET m ROWS FETCH NEXT n ROWS ONLY".
 func (b *SelectBuilder) Limit(limit uint64) *SelectBuilder {
 	b.limit = limit
 	b.limitValid = true
@@ -322,3 +581,27 @@ func (b *SelectBuilder) Suffix(sql string, args ...interface{}) *SelectBuilder {
 
 	return b
 }
+
+// Union returns a CompoundBuilder combining the query with selects using
+// UNION. The query comes first.
+func (b *SelectBuilder) Union(selects ...*SelectBuilder) *CompoundBuilder {
+	return NewCompoundBuilder(b.StatementBuilderType).Union(append([]*SelectBuilder{b}, selects...)...)
+}
+
+// UnionAll returns a CompoundBuilder combining the query with selects using
+// UNION ALL.
+func (b *SelectBuilder) UnionAll(selects ...*SelectBuilder) *CompoundBuilder {
+	return NewCompoundBuilder(b.StatementBuilderType).UnionAll(append([]*SelectBuilder{b}, selects...)...)
+}
+
+// Intersect returns a CompoundBuilder combining the query with selects using
+// INTERSECT.
+func (b *SelectBuilder) Intersect(selects ...*SelectBuilder) *CompoundBuilder {
+	return NewCompoundBuilder(b.StatementBuilderType).Intersect(append([]*SelectBuilder{b}, selects...)...)
+}
+
+// Except returns a CompoundBuilder combining the query with selects using
+// EXCEPT.
+func (b *SelectBuilder) Except(selects ...*SelectBuilder) *CompoundBuilder {
+	return NewCompoundBuilder(b.StatementBuilderType).Except(append([]*SelectBuilder{b}, selects...)...)
+}
@@ -110,6 +110,108 @@ func TestSelectBuilderToSqlErr(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSelectBuilderFromSelect(t *testing.T) {
+	sub := Select("c").From("d").Where(Eq{"i": 0})
+
+	sql, args, err := Select("a", "b").
+		FromSelect(sub, "subq").
+		Where("e = ?", 1).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a, b FROM (SELECT c FROM d WHERE i = $1) AS subq WHERE e = $2", sql)
+	assert.Equal(t, []interface{}{0, 1}, args)
+
+	_, _, err = Select("a").FromSelect(Select(), "subq").ToSQL()
+	assert.Error(t, err)
+}
+
+func TestSelectBuilderFromValues(t *testing.T) {
+	v := Values(1, "a").Values(2, Expr("UPPER(?)", "b"))
+
+	sql, args, err := Select("v.id", "t.name").
+		FromValues(v, "v", "id", "code").
+		Join("t ON t.code = v.code").
+		Where("t.x = ?", 3).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT v.id, t.name FROM (VALUES ($1,$2),($3,UPPER($4))) AS v(id, code) JOIN t ON t.code = v.code WHERE t.x = $5", sql)
+	assert.Equal(t, []interface{}{1, "a", 2, "b", 3}, args)
+}
+
+func TestSelectBuilderJoins(t *testing.T) {
+	sub := Select("user_id", "COUNT(*) AS n").From("orders").Where("status = ?", "paid").GroupBy("user_id")
+
+	sql, args, err := Select("*").
+		From("users u").
+		Join("emails e ON e.user_id = u.id AND e.tenant = ?", 1).
+		LeftJoinOn("phones p", Or{Expr("p.user_id = u.id"), Eq{"p.shared": true}}).
+		RightJoinOn("roles r", "r.id = u.role_id AND r.level > ?", 2).
+		JoinSelect(sub, "o", "o.user_id = u.id").
+		LeftJoinSelect(Select("id").From("bans").Where("until > ?", 3), "b", "b.id = u.id").
+		RightJoin("x USING (id)").
+		Where("u.id = ?", 4).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	expectedSQL := "SELECT * FROM users u " +
+		"JOIN emails e ON e.user_id = u.id AND e.tenant = $1 " +
+		"LEFT JOIN phones p ON (p.user_id = u.id OR p.shared = $2) " +
+		"RIGHT JOIN roles r ON r.id = u.role_id AND r.level > $3 " +
+		"JOIN (SELECT user_id, COUNT(*) AS n FROM orders WHERE status = $4 GROUP BY user_id) AS o ON o.user_id = u.id " +
+		"LEFT JOIN (SELECT id FROM bans WHERE until > $5) AS b ON b.id = u.id " +
+		"RIGHT JOIN x USING (id) " +
+		"WHERE u.id = $6"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []interface{}{1, true, 2, "paid", 3, 4}, args)
+
+	sql, _, err = Select("*").From("a").RightJoinSelect(Select("id").From("b"), "b", "b.id = a.id").ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM a RIGHT JOIN (SELECT id FROM b) AS b ON b.id = a.id", sql)
+}
+
+func TestSelectBuilderMoreJoins(t *testing.T) {
+	last := Select("o.id", "o.total").
+		From("orders o").
+		Where("o.user_id = u.id AND o.status = ?", "paid").
+		OrderBy("o.created_at DESC").
+		Limit(1)
+
+	sql, args, err := Select("*").
+		From("users u").
+		FullJoin("accounts a ON a.user_id = u.id AND a.x = ?", 1).
+		FullJoinOn("cards c", Eq{"c.y": 2}).
+		FullJoinSelect(Select("id").From("d"), "d", "d.id = u.d_id").
+		CrossJoin("regions").
+		CrossJoinSelect(Select("NOW() AS now"), "n").
+		NaturalJoin("profiles").
+		LeftJoinLateral(last, "lo", nil).
+		JoinLateral(Select("1").Where("u.z > ?", 3), "j", "j.id = u.id").
+		CrossJoinLateral(Select().Column("u.id * ? AS k", 4), "k").
+		Where("u.id = ?", 5).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	expectedSQL := "SELECT * FROM users u " +
+		"FULL OUTER JOIN accounts a ON a.user_id = u.id AND a.x = $1 " +
+		"FULL OUTER JOIN cards c ON c.y = $2 " +
+		"FULL OUTER JOIN (SELECT id FROM d) AS d ON d.id = u.d_id " +
+		"CROSS JOIN regions " +
+		"CROSS JOIN (SELECT NOW() AS now) AS n " +
+		"NATURAL JOIN profiles " +
+		"LEFT JOIN LATERAL (SELECT o.id, o.total FROM orders o WHERE o.user_id = u.id AND o.status = $3 ORDER BY o.created_at DESC LIMIT 1) AS lo ON TRUE " +
+		"JOIN LATERAL (SELECT 1 WHERE u.z > $4) AS j ON j.id = u.id " +
+		"CROSS JOIN LATERAL (SELECT u.id * $5 AS k) AS k " +
+		"WHERE u.id = $6"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []interface{}{1, 2, "paid", 3, 4, 5}, args)
+}
+
 func TestSelectBuilderPlaceholders(t *testing.T) {
 	b := Select("test").Where("x = ? AND y = ?")
 
@@ -66,14 +66,45 @@ type BaseRunner interface {
 	QueryerContext
 }
 
-type sqlWriter interface {
-	toSQL(b *bytes.Buffer) (args []interface{}, err error)
+// Sqlizer is the interface that wraps the WriteSQL method.
+//
+// WriteSQL writes a part of SQL statement to b, using question marks as
+// placeholders, and returns the args bound to these placeholders. Any type
+// implementing Sqlizer can be used wherever the builders accept expressions:
+// Where, Having, Column, Set, Values, Expr args etc.
+type Sqlizer interface {
+	WriteSQL(b *bytes.Buffer) (args []interface{}, err error)
 }
 
 type sqlBuilder interface {
 	ToSQL() (string, []interface{}, error)
 }
 
+type wrapper struct {
+	s sqlBuilder
+}
+
+// Wrap turns any value with a ToSQL method into a Sqlizer, so it can be
+// nested into the builders. The SQL returned by ToSQL must use question marks
+// as placeholders.
+//
+// Ex:
+//
+//	.Where(Wrap(myPredicate))
+func Wrap(s sqlBuilder) Sqlizer {
+	return wrapper{s}
+}
+
+func (w wrapper) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	sql, args, err := w.s.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	b.WriteString(sql)
+	return args, nil
+}
+
 // ExecWith Execs the SQL returned by s with db.
 func ExecWith(db Execer, s sqlBuilder) (res sql.Result, err error) {
 	query, args, err := s.ToSQL()
@@ -3,6 +3,9 @@ package sqrl
 import (
 	"context"
 	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 type DBStub struct {
@@ -68,3 +71,34 @@ func (s *DBStub) QueryRowContext(ctx context.Context, query string, args ...inte
 	s.LastQueryRowArgs = args
 	return &Row{RowScanner: &RowStub{}}
 }
+
+type toSQLStub struct{}
+
+func (toSQLStub) ToSQL() (string, []interface{}, error) {
+	return "b IN (?,?)", []interface{}{2, 3}, nil
+}
+
+func TestSqlizerNesting(t *testing.T) {
+	sql, args, err := Select("a").
+		From("t").
+		Where(dummySqlizer(1)).
+		Where(Wrap(toSQLStub{})).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM t WHERE DUMMY($1, $2) AND b IN ($3,$4)", sql)
+	assert.Equal(t, []interface{}{1, 1, 2, 3}, args)
+}
+
+func TestSqlizerInSetAndValues(t *testing.T) {
+	sql, args, err := Update("t").Set("a", dummySqlizer(1)).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE t SET a = DUMMY(?, ?)", sql)
+	assert.Equal(t, []interface{}{1, 1}, args)
+
+	sql, args, err = Insert("t").Values(Wrap(toSQLStub{})).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO t VALUES (b IN (?,?))", sql)
+	assert.Equal(t, []interface{}{2, 3}, args)
+}
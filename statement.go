@@ -3,9 +3,23 @@ package sqrl
 // StatementBuilderType is the type of StatementBuilder.
 type StatementBuilderType struct {
 	placeholderFormat PlaceholderFormat
+	dialect           Dialect
+	bindArrays        bool
 	runWith           BaseRunner
 }
 
+// renderOptions returns the options a builder renders with: its own dialect
+// if set, otherwise the dialect of the statement it is nested in.
+func (b StatementBuilderType) renderOptions(outer dialectOptions) dialectOptions {
+	if b.dialect != Generic {
+		outer.Dialect = b.dialect
+	}
+	if b.bindArrays {
+		outer.bindArrays = true
+	}
+	return outer
+}
+
 // Select returns a SelectBuilder for this StatementBuilder.
 func (b StatementBuilderType) Select(columns ...string) *SelectBuilder {
 	return NewSelectBuilder(b).Columns(columns...)
@@ -26,12 +40,56 @@ func (b StatementBuilderType) Delete(what ...string) *DeleteBuilder {
 	return NewDeleteBuilder(b).What(what...)
 }
 
+// Union returns a CompoundBuilder combining selects with UNION.
+func (b StatementBuilderType) Union(selects ...*SelectBuilder) *CompoundBuilder {
+	return NewCompoundBuilder(b).Union(selects...)
+}
+
+// UnionAll returns a CompoundBuilder combining selects with UNION ALL.
+func (b StatementBuilderType) UnionAll(selects ...*SelectBuilder) *CompoundBuilder {
+	return NewCompoundBuilder(b).UnionAll(selects...)
+}
+
+// Intersect returns a CompoundBuilder combining selects with INTERSECT.
+func (b StatementBuilderType) Intersect(selects ...*SelectBuilder) *CompoundBuilder {
+	return NewCompoundBuilder(b).Intersect(selects...)
+}
+
+// Except returns a CompoundBuilder combining selects with EXCEPT.
+func (b StatementBuilderType) Except(selects ...*SelectBuilder) *CompoundBuilder {
+	return NewCompoundBuilder(b).Except(selects...)
+}
+
 // PlaceholderFormat sets the PlaceholderFormat field for any child builders.
 func (b StatementBuilderType) PlaceholderFormat(f PlaceholderFormat) StatementBuilderType {
 	b.placeholderFormat = f
 	return b
 }
 
+// Dialect sets the Dialect field for any child builders.
+func (b StatementBuilderType) Dialect(d Dialect) StatementBuilderType {
+	b.dialect = d
+	return b
+}
+
+// BindArrays sets whether slice values of Eq and Neq are bound as a single
+// array parameter by child builders, see Array.
+func (b StatementBuilderType) BindArrays(enabled bool) StatementBuilderType {
+	b.bindArrays = enabled
+	return b
+}
+
+// QuoteIdent quotes an identifier for the Dialect of the builder, e.g.
+// "order" becomes `order` for MySQL. See Dialect.QuoteIdent.
+func (b StatementBuilderType) QuoteIdent(name string) string {
+	return b.dialect.QuoteIdent(name)
+}
+
+// QuoteIdents quotes identifiers for the Dialect of the builder.
+func (b StatementBuilderType) QuoteIdents(names ...string) []string {
+	return b.dialect.QuoteIdents(names...)
+}
+
 // RunWith sets the RunWith field for any child builders.
 func (b StatementBuilderType) RunWith(runner BaseRunner) StatementBuilderType {
 	b.runWith = runner
@@ -70,6 +128,34 @@ func Delete(what ...string) *DeleteBuilder {
 	return StatementBuilder.Delete(what...)
 }
 
+// Union returns a new CompoundBuilder combining selects with UNION.
+//
+// See CompoundBuilder.Union.
+func Union(selects ...*SelectBuilder) *CompoundBuilder {
+	return StatementBuilder.Union(selects...)
+}
+
+// UnionAll returns a new CompoundBuilder combining selects with UNION ALL.
+//
+// See CompoundBuilder.UnionAll.
+func UnionAll(selects ...*SelectBuilder) *CompoundBuilder {
+	return StatementBuilder.UnionAll(selects...)
+}
+
+// Intersect returns a new CompoundBuilder combining selects with INTERSECT.
+//
+// See CompoundBuilder.Intersect.
+func Intersect(selects ...*SelectBuilder) *CompoundBuilder {
+	return StatementBuilder.Intersect(selects...)
+}
+
+// Except returns a new CompoundBuilder combining selects with EXCEPT.
+//
+// See CompoundBuilder.Except.
+func Except(selects ...*SelectBuilder) *CompoundBuilder {
+	return StatementBuilder.Except(selects...)
+}
+
 // Case returns a new CaseBuilder
 // "what" represents case value
 func Case(what ...interface{}) *CaseBuilder {
@@ -43,3 +43,11 @@ func TestRunWithTx(t *testing.T) {
 		Delete("t").RunWith(tx)
 	}, "RunWith(*sql.Tx) should not panic")
 }
+
+func TestStatementBuilderDialect(t *testing.T) {
+	sb := StatementBuilder.Dialect(SQLServer)
+
+	sql, _, err := sb.Insert("a").Values(1).Returning("id").ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a OUTPUT INSERTED.id VALUES (?)", sql)
+}
@@ -0,0 +1,108 @@
+package sqrl
+
+import (
+	"bytes"
+	"strings"
+)
+
+// isSubquery reports whether s renders a whole query, which must be enclosed
+// in parentheses when used as an operand.
+func isSubquery(s Sqlizer) bool {
+	switch v := s.(type) {
+	case *SelectBuilder, *CompoundBuilder, *ValuesBuilder:
+		return true
+	case wrapper:
+		inner, ok := v.s.(Sqlizer)
+		return ok && isSubquery(inner)
+	}
+	return false
+}
+
+// operandToSQL renders s used as a value of a predicate. subquery reports
+// whether it is a query, either a builder or any Sqlizer, e.g. Expr, rendering
+// a statement starting with SELECT, WITH, VALUES or TABLE followed by a space.
+// Function calls like MySQL VALUES(col) are not queries. An alias has no
+// meaning in a predicate, so an aliased expression is rendered alone.
+func operandToSQL(s Sqlizer, d dialectOptions) (sql string, args []interface{}, subquery bool, err error) {
+	if a, ok := s.(aliasExpr); ok {
+		s = a.expr
+	}
+
+	b := &bytes.Buffer{}
+	if args, err = writeSQL(b, s, d); err != nil {
+		return
+	}
+	sql = b.String()
+
+	if isSubquery(s) {
+		return sql, args, true, nil
+	}
+
+	keyword := strings.TrimLeft(sql, " \t\r\n")
+	end := strings.IndexAny(keyword, " \t\r\n(")
+	if end == -1 || keyword[end] == '(' {
+		return
+	}
+	keyword = keyword[:end]
+
+	switch strings.ToUpper(keyword) {
+	case "SELECT", "WITH", "VALUES", "TABLE":
+		subquery = true
+	}
+
+	return
+}
+
+// subqueryExpr is a query preceded by a keyword: "EXISTS (query)".
+type subqueryExpr struct {
+	keyword string
+	query   Sqlizer
+}
+
+// WriteSQL implements Sqlizer.
+func (e subqueryExpr) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return e.writeDialectSQL(b, dialectOptions{})
+}
+
+func (e subqueryExpr) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	b.WriteString(e.keyword + " (")
+
+	args, err = writeSQL(b, e.query, d)
+	if err != nil {
+		return
+	}
+
+	b.WriteByte(')')
+
+	return
+}
+
+// Exists builds "EXISTS (query)" predicate.
+// Ex:
+//
+//	.Where(Exists(Select("1").From("orders o").Where("o.user_id = u.id")))
+func Exists(query Sqlizer) Sqlizer {
+	return subqueryExpr{keyword: "EXISTS", query: query}
+}
+
+// NotExists builds "NOT EXISTS (query)" predicate.
+func NotExists(query Sqlizer) Sqlizer {
+	return subqueryExpr{keyword: "NOT EXISTS", query: query}
+}
+
+// Any builds "ANY (query)" operand which compares a value with each row of
+// the subquery. Use it as a value of Eq, Lt, Gt and similar predicates or Op.
+// Ex:
+//
+//	.Where(Gt{"price": Any(Select("price").From("offers"))}) == "price > ANY (SELECT price FROM offers)"
+func Any(query Sqlizer) Sqlizer {
+	return subqueryExpr{keyword: "ANY", query: query}
+}
+
+// All builds "ALL (query)" operand. See Any.
+// Ex:
+//
+//	.Where(Op{"price", ">", All(Select("price").From("offers"))})
+func All(query Sqlizer) Sqlizer {
+	return subqueryExpr{keyword: "ALL", query: query}
+}
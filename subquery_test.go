@@ -0,0 +1,96 @@
+package sqrl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqSubquery(t *testing.T) {
+	admins := Select("user_id").From("admins").Where("level > ?", 1)
+
+	sql, args, err := Select("*").
+		From("users").
+		Where(Eq{"id": admins}).
+		Where(Neq{"team_id": Union(Select("id").From("a"), Select("id").From("b").Where("x = ?", 2))}).
+		Where(EqList{{"created_at", Expr("NOW()")}}).
+		Where(Lt{"score": Select("AVG(score)").From("users")}).
+		Where(Op{"rank", "<=", Select("MAX(rank)").From("r").Where("y = ?", 3)}).
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	expectedSQL := "SELECT * FROM users " +
+		"WHERE id IN (SELECT user_id FROM admins WHERE level > $1) " +
+		"AND team_id NOT IN (SELECT id FROM a UNION SELECT id FROM b WHERE x = $2) " +
+		"AND created_at = NOW() " +
+		"AND score < (SELECT AVG(score) FROM users) " +
+		"AND rank <= (SELECT MAX(rank) FROM r WHERE y = $3)"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []interface{}{1, 2, 3}, args)
+}
+
+func TestEqSubqueryTypes(t *testing.T) {
+	sql, args, err := Select("*").
+		From("t").
+		Where(Eq{"a": Values(1).Values(2)}).
+		Where(Eq{"b": Wrap(Select("id").From("u").Where("x = ?", 3))}).
+		Where(Eq{"c": Alias(Select("id").From("v"), "v")}).
+		Where(Lt{"d": Expr("SELECT MAX(d) FROM w")}).
+		Where(Op{"e", "@>", Expr("with q AS (SELECT 1) SELECT * FROM q")}).
+		Where(Eq{"f": Expr("VALUES(g)")}).
+		Dialect(Postgres).
+		ToSQL()
+
+	assert.NoError(t, err)
+	expectedSQL := "SELECT * FROM t " +
+		"WHERE a IN (VALUES (?),(?)) " +
+		"AND b IN (SELECT id FROM u WHERE x = ?) " +
+		"AND c IN (SELECT id FROM v) " +
+		"AND d < (SELECT MAX(d) FROM w) " +
+		"AND e @> (with q AS (SELECT 1) SELECT * FROM q) " +
+		"AND f = VALUES(g)"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []interface{}{1, 2, 3}, args)
+}
+
+// Expr values used to be bound as args, they are rendered in place since
+// subqueries are supported.
+func TestEqExprValue(t *testing.T) {
+	sql, args, err := Select("*").
+		From("t").
+		Where(Eq{"a": Expr("LOWER(?)", "X"), "b": Expr("NOW()")}).
+		Where(Neq{"c": Expr("selected")}).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE a = LOWER(?) AND b = NOW() AND c <> selected", sql)
+	assert.Equal(t, []interface{}{"X"}, args)
+}
+
+func TestExists(t *testing.T) {
+	sql, args, err := Select("*").
+		From("users u").
+		Where(Exists(Select("1").From("orders o").Where("o.user_id = u.id AND o.total > ?", 10))).
+		Where(NotExists(Select("1").From("bans b").Where("b.user_id = u.id"))).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users u WHERE EXISTS (SELECT 1 FROM orders o WHERE o.user_id = u.id AND o.total > ?) AND NOT EXISTS (SELECT 1 FROM bans b WHERE b.user_id = u.id)", sql)
+	assert.Equal(t, []interface{}{10}, args)
+}
+
+func TestAnyAll(t *testing.T) {
+	offers := Select("price").From("offers").Where("active = ?", true)
+
+	sql, args, err := Select("*").
+		From("products").
+		Where(Eq{"price": Any(offers)}).
+		Where(Op{"price", ">", All(offers)}).
+		Where(Gte{"price": Any(Select("price").From("old"))}).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM products WHERE price = ANY (SELECT price FROM offers WHERE active = ?) AND price > ALL (SELECT price FROM offers WHERE active = ?) AND price >= ANY (SELECT price FROM old)", sql)
+	assert.Equal(t, []interface{}{true, true}, args)
+}
@@ -0,0 +1,254 @@
+package sqrl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TupleIn is a composite key IN predicate: "(a, b) IN ((?,?),(?,?))".
+// SQLite dialect gets "(a, b) IN (VALUES (?,?),(?,?))". SQLServer dialect
+// has no row values, so it gets
+// "((a = ? AND b = ?) OR (a = ? AND b = ?))" instead. Sqlizer values are
+// rendered in place of their placeholders, like in Op.
+// Ex:
+//
+//	.Where(TupleIn{[]string{"tenant_id", "id"}, [][]interface{}{{1, 10}, {1, 11}}})
+type TupleIn struct {
+	Columns []string
+	Values  [][]interface{}
+}
+
+// WriteSQL implements Sqlizer.
+func (t TupleIn) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return t.writeDialectSQL(b, dialectOptions{})
+}
+
+func (t TupleIn) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return tupleInToSQL(b, t.Columns, t.Values, d, false)
+}
+
+// TupleNotIn is the negation of TupleIn.
+type TupleNotIn struct {
+	Columns []string
+	Values  [][]interface{}
+}
+
+// WriteSQL implements Sqlizer.
+func (t TupleNotIn) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return t.writeDialectSQL(b, dialectOptions{})
+}
+
+func (t TupleNotIn) writeDialectSQL(b *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	return tupleInToSQL(b, t.Columns, t.Values, d, true)
+}
+
+// TupleOp is a row value comparison, e.g. "(created_at, id) > (?,?)", which is
+// handy for keyset pagination. Rows are compared in lexicographic order, so
+// dialects without row value comparisons, SQLServer and Oracle, get an
+// equivalent expansion: "(created_at > ? OR (created_at = ? AND id > ?))".
+// Ex:
+//
+//	.Where(TupleOp{[]string{"created_at", "id"}, ">", []interface{}{lastAt, lastID}})
+type TupleOp struct {
+	Columns  []string
+	Operator string
+	Values   []interface{}
+}
+
+// WriteSQL implements Sqlizer.
+func (t TupleOp) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	return t.writeDialectSQL(b, dialectOptions{})
+}
+
+func (t TupleOp) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	if err = checkTuple(t.Columns, t.Values); err != nil {
+		return
+	}
+
+	var strict string
+	switch t.Operator {
+	case "<", "<=":
+		strict = "<"
+	case ">", ">=":
+		strict = ">"
+	case "=", "<>", "!=":
+	default:
+		return nil, fmt.Errorf("unsupported row comparison operator %s", t.Operator)
+	}
+
+	if d.Dialect != SQLServer && (d.Dialect != Oracle || strict == "") {
+		b.WriteString("(" + strings.Join(t.Columns, ", ") + ") " + t.Operator + " ")
+		return appendTupleToSQL(b, t.Values, d, args)
+	}
+
+	b.WriteByte('(')
+
+	switch strict {
+	case "":
+		sep, opr := " AND ", " = "
+		if t.Operator != "=" {
+			sep, opr = " OR ", " <> "
+		}
+
+		for i, column := range t.Columns {
+			if i > 0 {
+				b.WriteString(sep)
+			}
+			b.WriteString(column + opr)
+			if args, err = tupleValueToSQL(b, t.Values[i], d, args); err != nil {
+				return
+			}
+		}
+	default:
+		// the rows differ at the first unequal column: "a > ? OR (a = ? AND b > ?)"
+		for i, column := range t.Columns {
+			if i > 0 {
+				b.WriteString(" OR (")
+			}
+
+			for j := 0; j < i; j++ {
+				b.WriteString(t.Columns[j] + " = ")
+				if args, err = tupleValueToSQL(b, t.Values[j], d, args); err != nil {
+					return
+				}
+				b.WriteString(" AND ")
+			}
+
+			opr := strict
+			if i == len(t.Columns)-1 {
+				opr = t.Operator
+			}
+			b.WriteString(column + " " + opr + " ")
+			if args, err = tupleValueToSQL(b, t.Values[i], d, args); err != nil {
+				return
+			}
+
+			if i > 0 {
+				b.WriteByte(')')
+			}
+		}
+	}
+
+	b.WriteByte(')')
+
+	return
+}
+
+func tupleInToSQL(b *bytes.Buffer, columns []string, tuples [][]interface{}, d dialectOptions, useNotOpr bool) (args []interface{}, err error) {
+	if len(tuples) == 0 {
+		return nil, errors.New("tuple in condition must contain at least one tuple")
+	}
+
+	for _, tuple := range tuples {
+		if err = checkTuple(columns, tuple); err != nil {
+			return
+		}
+	}
+
+	if d.Dialect != SQLServer {
+		b.WriteString("(" + strings.Join(columns, ", ") + ")")
+		if useNotOpr {
+			b.WriteString(" NOT IN (")
+		} else {
+			b.WriteString(" IN (")
+		}
+
+		// SQLite accepts only a subquery on the right of row value IN
+		if d.Dialect == SQLite {
+			b.WriteString("VALUES ")
+		}
+
+		for i, tuple := range tuples {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if args, err = appendTupleToSQL(b, tuple, d, args); err != nil {
+				return
+			}
+		}
+
+		b.WriteByte(')')
+		return
+	}
+
+	if useNotOpr {
+		b.WriteString("NOT ")
+	}
+
+	b.WriteByte('(')
+	for i, tuple := range tuples {
+		if i > 0 {
+			b.WriteString(" OR ")
+		}
+
+		b.WriteByte('(')
+		for j, column := range columns {
+			if j > 0 {
+				b.WriteString(" AND ")
+			}
+			b.WriteString(column + " = ")
+			if args, err = tupleValueToSQL(b, tuple[j], d, args); err != nil {
+				return
+			}
+		}
+		b.WriteByte(')')
+	}
+	b.WriteByte(')')
+
+	return
+}
+
+// appendTupleToSQL writes row value "(?,?)" of values.
+func appendTupleToSQL(b *bytes.Buffer, values []interface{}, d dialectOptions, args []interface{}) ([]interface{}, error) {
+	b.WriteByte('(')
+
+	for i, val := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		var err error
+		if args, err = tupleValueToSQL(b, val, d, args); err != nil {
+			return nil, err
+		}
+	}
+
+	b.WriteByte(')')
+
+	return args, nil
+}
+
+// tupleValueToSQL writes a value of a tuple: a Sqlizer in place, a subquery
+// in parentheses or a placeholder.
+func tupleValueToSQL(b *bytes.Buffer, val interface{}, d dialectOptions, args []interface{}) ([]interface{}, error) {
+	s, ok := val.(Sqlizer)
+	if !ok {
+		b.WriteByte('?')
+		return append(args, val), nil
+	}
+
+	sql, valArgs, subquery, err := operandToSQL(s, d)
+	if err != nil {
+		return nil, err
+	}
+
+	if subquery {
+		sql = "(" + sql + ")"
+	}
+	b.WriteString(sql)
+
+	return append(args, valArgs...), nil
+}
+
+// checkTuple returns an error if the values don't match the columns.
+func checkTuple(columns []string, values []interface{}) error {
+	if len(columns) == 0 {
+		return errors.New("tuple must have at least one column")
+	}
+	if len(values) != len(columns) {
+		return fmt.Errorf("tuple has %d values, expected %d", len(values), len(columns))
+	}
+	return nil
+}
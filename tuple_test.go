@@ -0,0 +1,78 @@
+package sqrl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTupleIn(t *testing.T) {
+	pred := TupleIn{[]string{"a", "b"}, [][]interface{}{{1, 2}, {3, 4}}}
+
+	b := &bytes.Buffer{}
+	args, err := pred.WriteSQL(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "(a, b) IN ((?,?),(?,?))", b.String())
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, args)
+
+	sql, args, err := Select("*").From("t").Where(pred).Where(TupleNotIn{[]string{"c", "d"}, [][]interface{}{{5, 6}}}).Dialect(SQLServer).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE ((a = ? AND b = ?) OR (a = ? AND b = ?)) AND NOT ((c = ? AND d = ?))", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5, 6}, args)
+
+	sql, _, err = Select("*").From("t").Where(TupleNotIn{[]string{"c", "d"}, [][]interface{}{{5, 6}}}).Dialect(Oracle).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE (c, d) NOT IN ((?,?))", sql)
+
+	sql, args, err = Select("*").From("t").Where(pred).Where(TupleNotIn{[]string{"c", "d"}, [][]interface{}{{5, 6}}}).Dialect(SQLite).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE (a, b) IN (VALUES (?,?),(?,?)) AND (c, d) NOT IN (VALUES (?,?))", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5, 6}, args)
+
+	_, err = TupleIn{[]string{"a", "b"}, [][]interface{}{{1, 2}, {3}}}.WriteSQL(b)
+	assert.Error(t, err)
+
+	_, err = TupleIn{[]string{"a"}, nil}.WriteSQL(b)
+	assert.Error(t, err)
+
+	_, err = TupleIn{nil, [][]interface{}{{}}}.WriteSQL(b)
+	assert.Error(t, err)
+
+	b.Reset()
+	args, err = TupleIn{[]string{"a", "b"}, [][]interface{}{{1, Expr("NOW()")}, {2, Expr("? + 1", 3)}}}.WriteSQL(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "(a, b) IN ((?,NOW()),(?,? + 1))", b.String())
+	assert.Equal(t, []interface{}{1, 2, 3}, args)
+}
+
+func TestTupleOp(t *testing.T) {
+	cases := []struct {
+		d        Dialect
+		pred     TupleOp
+		expected string
+		args     []interface{}
+	}{
+		{Postgres, TupleOp{[]string{"a", "b"}, ">", []interface{}{1, 2}}, "(a, b) > (?,?)", []interface{}{1, 2}},
+		{Oracle, TupleOp{[]string{"a", "b"}, "=", []interface{}{1, 2}}, "(a, b) = (?,?)", []interface{}{1, 2}},
+		{SQLServer, TupleOp{[]string{"a", "b"}, "=", []interface{}{1, 2}}, "(a = ? AND b = ?)", []interface{}{1, 2}},
+		{SQLServer, TupleOp{[]string{"a", "b"}, "<>", []interface{}{1, 2}}, "(a <> ? OR b <> ?)", []interface{}{1, 2}},
+		{Oracle, TupleOp{[]string{"a", "b", "c"}, ">=", []interface{}{1, 2, 3}}, "(a > ? OR (a = ? AND b > ?) OR (a = ? AND b = ? AND c >= ?))", []interface{}{1, 1, 2, 1, 2, 3}},
+		{SQLServer, TupleOp{[]string{"a"}, "<", []interface{}{1}}, "(a < ?)", []interface{}{1}},
+		{Postgres, TupleOp{[]string{"a", "b"}, "<", []interface{}{Expr("NOW()"), 1}}, "(a, b) < (NOW(),?)", []interface{}{1}},
+		{SQLServer, TupleOp{[]string{"a", "b"}, ">", []interface{}{Expr("? - 1", 2), 1}}, "(a > ? - 1 OR (a = ? - 1 AND b > ?))", []interface{}{2, 2, 1}},
+	}
+
+	for _, c := range cases {
+		sql, args, err := Select("*").From("t").Where(c.pred).Dialect(c.d).ToSQL()
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM t WHERE "+c.expected, sql)
+		assert.Equal(t, c.args, args)
+	}
+
+	_, err := TupleOp{[]string{"a", "b"}, ">", []interface{}{1}}.WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+
+	_, err = TupleOp{[]string{"a"}, "LIKE", []interface{}{1}}.WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"strconv"
 	"strings"
 )
 
@@ -14,22 +13,59 @@ type setClause struct {
 	value  interface{}
 }
 
+func addSetClause(clauses []setClause, column string, value interface{}) []setClause {
+	for i := range clauses {
+		if clauses[i].column == column {
+			clauses[i].value = value
+			return clauses
+		}
+	}
+
+	return append(clauses, setClause{column: column, value: value})
+}
+
+func appendSetClausesToSQL(b *bytes.Buffer, clauses []setClause, d dialectOptions, args []interface{}) ([]interface{}, error) {
+	for i, c := range clauses {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString(c.column + " = ")
+
+		switch typedVal := c.value.(type) {
+		case Sqlizer:
+			valArgs, err := writeSQL(b, typedVal, d)
+			if err != nil {
+				return nil, err
+			}
+			if len(valArgs) != 0 {
+				args = append(args, valArgs...)
+			}
+		default:
+			b.WriteString("?")
+			args = append(args, typedVal)
+		}
+	}
+
+	return args, nil
+}
+
 // Builder
 
 // UpdateBuilder builds SQL UPDATE statements.
 type UpdateBuilder struct {
 	StatementBuilderType
 
+	ctes       []cte
 	prefixes   []expr
 	table      string
-	setClauses map[string]interface{}
-	whereParts []sqlWriter
+	setClauses []setClause
+	whereParts []Sqlizer
 	orderBys   []string
 
-	limit       uint64
-	limitValid  bool
-	offset      uint64
-	offsetValid bool
+	pagination
+
+	returning []string
 
 	suffixes []expr
 }
@@ -58,6 +94,41 @@ func (b *UpdateBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
 	return ExecWithContext(ctx, b.runWith, b)
 }
 
+// Query builds and Querys the query with the Runner set by RunWith.
+func (b *UpdateBuilder) Query() (*sql.Rows, error) {
+	return b.QueryContext(context.Background())
+}
+
+// QueryContext builds and runs the query using given context and Query command.
+func (b *UpdateBuilder) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	if b.runWith == nil {
+		return nil, ErrRunnerNotSet
+	}
+	return QueryWithContext(ctx, b.runWith, b)
+}
+
+// QueryRow builds and QueryRows the query with the Runner set by RunWith.
+func (b *UpdateBuilder) QueryRow() RowScanner {
+	return b.QueryRowContext(context.Background())
+}
+
+// QueryRowContext builds and runs the query using given context.
+func (b *UpdateBuilder) QueryRowContext(ctx context.Context) RowScanner {
+	if b.runWith == nil {
+		return &Row{err: ErrRunnerNotSet}
+	}
+	queryRower, ok := b.runWith.(QueryRowerContext)
+	if !ok {
+		return &Row{err: ErrRunnerNotQueryRunnerContext}
+	}
+	return QueryRowWithContext(ctx, queryRower, b)
+}
+
+// Scan is a shortcut for QueryRow().Scan.
+func (b *UpdateBuilder) Scan(dest ...interface{}) error {
+	return b.QueryRow().Scan(dest...)
+}
+
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
 func (b *UpdateBuilder) PlaceholderFormat(f PlaceholderFormat) *UpdateBuilder {
@@ -65,8 +136,41 @@ func (b *UpdateBuilder) PlaceholderFormat(f PlaceholderFormat) *UpdateBuilder {
 	return b
 }
 
-// ToSql builds the query into a SQL string and bound args.
+// Dialect sets Dialect (e.g. Postgres or SQLServer) for the query.
+func (b *UpdateBuilder) Dialect(d Dialect) *UpdateBuilder {
+	b.dialect = d
+	return b
+}
+
+// BindArrays sets whether slice values of Eq and Neq are bound as a single
+// array parameter, see Array.
+func (b *UpdateBuilder) BindArrays(enabled bool) *UpdateBuilder {
+	b.bindArrays = enabled
+	return b
+}
+
+// ToSQL builds the query into a SQL string and bound args.
 func (b *UpdateBuilder) ToSQL() (sqlStr string, args []interface{}, err error) {
+	sql := &bytes.Buffer{}
+	args, err = b.WriteSQL(sql)
+	if err != nil {
+		return
+	}
+
+	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
+	args = namedArgsLast(args)
+	return
+}
+
+// WriteSQL implements Sqlizer, so the statement can be used within other
+// queries, e.g. as a data-modifying WITH query.
+func (b *UpdateBuilder) WriteSQL(sql *bytes.Buffer) (args []interface{}, err error) {
+	return b.writeDialectSQL(sql, dialectOptions{})
+}
+
+func (b *UpdateBuilder) writeDialectSQL(sql *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	d = b.renderOptions(d)
+
 	if len(b.table) == 0 {
 		err = errors.New("update statements must specify a table")
 		return
@@ -76,77 +180,104 @@ func (b *UpdateBuilder) ToSQL() (sqlStr string, args []interface{}, err error) {
 		return
 	}
 
-	sql := &bytes.Buffer{}
+	if err = checkReturning(b.returning, d.Dialect); err != nil {
+		return
+	}
+
+	if err = b.checkModify(d.Dialect, "UPDATE", len(b.orderBys) > 0); err != nil {
+		return
+	}
 
 	if len(b.prefixes) > 0 {
-		args, _ = appendExpressionsToSQL(sql, b.prefixes, " ", args)
+		args, err = appendExpressionsToSQL(sql, b.prefixes, " ", d, args)
+		if err != nil {
+			return
+		}
 		sql.WriteString(" ")
 	}
 
+	if len(b.ctes) > 0 {
+		args, err = appendCTEsToSQL(sql, b.ctes, d, args)
+		if err != nil {
+			return
+		}
+	}
+
 	sql.WriteString("UPDATE ")
-	sql.WriteString(b.table)
 
-	sql.WriteString(" SET ")
-	i := 0
-	for column, value := range b.setClauses {
-		if i > 0 {
-			sql.WriteString(", ")
-		}
+	top := b.useTop(d.Dialect)
+	if top {
+		b.writeTop(sql)
+	}
 
-		sql.WriteString(column + " = ")
+	sql.WriteString(b.table)
 
-		switch typedVal := value.(type) {
-		case sqlWriter:
-			var valArgs []interface{}
-			valArgs, err = typedVal.toSQL(sql)
-			if err != nil {
-				return
-			}
-			if len(valArgs) != 0 {
-				args = append(args, valArgs...)
-			}
-		default:
-			sql.WriteString("?")
-			args = append(args, typedVal)
-		}
+	sql.WriteString(" SET ")
+	args, err = appendSetClausesToSQL(sql, b.setClauses, d, args)
+	if err != nil {
+		return
+	}
 
-		i++
+	if len(b.returning) > 0 && d.Dialect == SQLServer {
+		sql.WriteString(" ")
+		appendOutputToSQL(sql, b.returning, "INSERTED")
 	}
 
 	if len(b.whereParts) > 0 {
 		sql.WriteString(" WHERE ")
-		args, err = appendToSQL(b.whereParts, sql, " AND ", args)
+		args, err = appendToSQL(b.whereParts, sql, " AND ", d, args)
 		if err != nil {
 			return
 		}
 	}
 
+	if len(b.returning) > 0 && d.Dialect == SQLite {
+		sql.WriteString(" ")
+		appendReturningToSQL(sql, b.returning)
+	}
+
 	if len(b.orderBys) > 0 {
 		sql.WriteString(" ORDER BY ")
 		sql.WriteString(strings.Join(b.orderBys, ", "))
 	}
 
-	if b.limitValid {
-		sql.WriteString(" LIMIT ")
-		sql.WriteString(strconv.FormatUint(b.limit, 10))
+	if !top {
+		b.writeLimitSQL(sql)
 	}
 
-	if b.offsetValid {
-		sql.WriteString(" OFFSET ")
-		sql.WriteString(strconv.FormatUint(b.offset, 10))
+	if len(b.returning) > 0 && d.Dialect != SQLite && d.Dialect != SQLServer {
+		sql.WriteString(" ")
+		appendReturningToSQL(sql, b.returning)
 	}
 
 	if len(b.suffixes) > 0 {
 		sql.WriteString(" ")
-		args, _ = appendExpressionsToSQL(sql, b.suffixes, " ", args)
+		args, err = appendExpressionsToSQL(sql, b.suffixes, " ", d, args)
+		if err != nil {
+			return
+		}
 	}
 
-	sqlStr, err = b.placeholderFormat.ReplacePlaceholders(sql.String())
 	return
 }
 
 // SQL methods
 
+// With adds a named query to WITH clause of the query. The query can be
+// any Sqlizer, e.g. SelectBuilder or a data-modifying statement like
+// InsertBuilder. Its args precede the args of the main query.
+func (b *UpdateBuilder) With(name string, query Sqlizer) *UpdateBuilder {
+	b.ctes = append(b.ctes, cte{name: name, query: query})
+	return b
+}
+
+// WithRecursive adds a recursive named query with optional column names to
+// WITH clause of the query, usually a CompoundBuilder.
+func (b *UpdateBuilder) WithRecursive(name string, columns []string, query Sqlizer) *UpdateBuilder {
+	b.ctes = append(b.ctes, cte{name: name, columns: columns, recursive: true, query: query})
+	return b
+}
+
 // Prefix adds an expression to the beginning of the query
 func (b *UpdateBuilder) Prefix(sql string, args ...interface{}) *UpdateBuilder {
 	b.prefixes = append(b.prefixes, Expr(sql, args...))
@@ -160,29 +291,71 @@ func (b *UpdateBuilder) Table(table string) *UpdateBuilder {
 }
 
 // Set adds SET clauses to the query.
+//
+// Clauses are rendered in the order they were added. Setting the same column
+// again replaces its value but keeps its position.
 func (b *UpdateBuilder) Set(column string, value interface{}) *UpdateBuilder {
-	if b.setClauses == nil {
-		b.setClauses = map[string]interface{}{column: value}
-	} else {
-		b.setClauses[column] = value
-	}
-
+	b.setClauses = addSetClause(b.setClauses, column, value)
 	return b
 }
 
 // SetMap is a convenience method which calls .Set for each key/value pair in clauses.
+// The pairs are added in the order of sorted keys.
 func (b *UpdateBuilder) SetMap(clauses map[string]interface{}) *UpdateBuilder {
-	if b.setClauses == nil {
-		b.setClauses = clauses
-	} else {
-		for k, v := range clauses {
-			b.setClauses[k] = v
-		}
+	for _, p := range sortedPairs(clauses) {
+		b.Set(p.Column, p.Value)
 	}
 
 	return b
 }
 
+// SetJSONPath adds SET clause updating an element of JSON document in column.
+// Path elements are object keys (strings) or array indexes (ints). The value
+// is JSON encoded unless it's []byte, json.RawMessage or a Sqlizer.
+// Ex:
+//
+//	.SetJSONPath("data", []interface{}{"a", "b"}, 1)
+//
+// renders "data = jsonb_set(data, '{a,b}', ?::jsonb)" for Postgres,
+// "data = JSON_SET(data, '$.a.b', CAST(? AS JSON))" for MySQL,
+// "data = JSON_MODIFY(data, '$.a.b', ?)" for SQLServer and the equivalent
+// functions for SQLite and Oracle. SQLServer passes objects and arrays
+// through JSON_QUERY and binds scalars as they are.
+//
+// JSON updates of the same column are combined into one SET clause. If the
+// column has been already set to another value, the update applies to that
+// value, which is JSON encoded unless it's a Sqlizer.
+func (b *UpdateBuilder) SetJSONPath(column string, path []interface{}, value interface{}) *UpdateBuilder {
+	return b.setJSON(jsonUpdate{column: column, op: jsonSet, path: path, value: value})
+}
+
+// RemoveJSONPath adds SET clause removing an element of JSON document in
+// column, e.g. "data = data #- '{a,b}'" for Postgres and
+// "data = JSON_REMOVE(data, '$.a.b')" for MySQL.
+func (b *UpdateBuilder) RemoveJSONPath(column string, path ...interface{}) *UpdateBuilder {
+	return b.setJSON(jsonUpdate{column: column, op: jsonRemove, path: path})
+}
+
+// AppendJSONArray adds SET clause appending value to JSON array at path of
+// the document in column, e.g.
+// "data = jsonb_insert(data, '{tags,-1}', ?::jsonb, true)" for Postgres and
+// "data = JSON_ARRAY_APPEND(data, '$.tags', CAST(? AS JSON))" for MySQL and
+// "data = JSON_MODIFY(data, 'append $.tags', ?)" for SQLServer.
+// The value is encoded as in SetJSONPath.
+func (b *UpdateBuilder) AppendJSONArray(column string, path []interface{}, value interface{}) *UpdateBuilder {
+	return b.setJSON(jsonUpdate{column: column, op: jsonAppend, path: path, value: value})
+}
+
+func (b *UpdateBuilder) setJSON(u jsonUpdate) *UpdateBuilder {
+	for _, c := range b.setClauses {
+		if c.column == u.column {
+			u.doc, u.hasDoc = c.value, true
+		}
+	}
+
+	return b.Set(u.column, u)
+}
+
 // Where adds WHERE expressions to the query.
 //
 // See SelectBuilder.Where for more information.
@@ -197,20 +370,30 @@ func (b *UpdateBuilder) OrderBy(orderBys ...string) *UpdateBuilder {
 	return b
 }
 
-// Limit sets a LIMIT clause on the query.
+// Limit sets a LIMIT clause on the query. It is rendered as "TOP (n)" for
+// SQLServer dialect, while Postgres and Oracle dialects return an error.
 func (b *UpdateBuilder) Limit(limit uint64) *UpdateBuilder {
 	b.limit = limit
 	b.limitValid = true
 	return b
 }
 
-// Offset sets a OFFSET clause on the query.
+// Offset sets a OFFSET clause on the query. Only Generic and SQLite dialects
+// support it.
 func (b *UpdateBuilder) Offset(offset uint64) *UpdateBuilder {
 	b.offset = offset
 	b.offsetValid = true
 	return b
 }
 
+// Returning adds columns to RETURNING clause of the query. The clause is
+// rendered as "OUTPUT INSERTED.column" for SQLServer dialect.
+// MySQL and Oracle dialects return an error.
+func (b *UpdateBuilder) Returning(columns ...string) *UpdateBuilder {
+	b.returning = append(b.returning, columns...)
+	return b
+}
+
 // Suffix adds an expression to the end of the query
 func (b *UpdateBuilder) Suffix(sql string, args ...interface{}) *UpdateBuilder {
 	b.suffixes = append(b.suffixes, Expr(sql, args...))
@@ -2,6 +2,7 @@ package sqrl
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -69,6 +70,18 @@ func TestUpdateBuilderPlaceholders(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestUpdateBuilderSetOrder(t *testing.T) {
+	sql, args, err := Update("test").
+		Set("z", 1).
+		SetMap(map[string]interface{}{"y": 2, "b": 3, "a": 4}).
+		Set("z", 5).
+		ToSQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE test SET z = ?, a = ?, b = ?, y = ?", sql)
+	assert.Equal(t, []interface{}{5, 4, 3, 2}, args)
+}
+
 func TestUpdateBuilderRunners(t *testing.T) {
 	db := &DBStub{}
 	b := Update("test").Set("x", 1).RunWith(db)
@@ -82,12 +95,60 @@ func TestUpdateBuilderRunners(t *testing.T) {
 	assert.Equal(t, expectedSQL, db.LastExecSql)
 }
 
+func TestUpdateBuilderQueryRunners(t *testing.T) {
+	db := &DBStub{}
+	b := Update("test").Set("x", 1).Returning("id").RunWith(db)
+
+	expectedSQL := "UPDATE test SET x = ? RETURNING id"
+
+	b.Query()
+	assert.Equal(t, expectedSQL, db.LastQuerySql)
+
+	b.QueryContext(context.TODO())
+	assert.Equal(t, expectedSQL, db.LastQuerySql)
+
+	b.QueryRow()
+	assert.Equal(t, expectedSQL, db.LastQueryRowSql)
+
+	err := b.Scan()
+	assert.NoError(t, err)
+}
+
+func TestUpdateBuilderReturning(t *testing.T) {
+	b := Update("a").Set("b", 1).Where("c = ?", 2).OrderBy("d").Limit(3).Returning("id", "b")
+
+	sql, args, err := b.ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE a SET b = ? WHERE c = ? ORDER BY d LIMIT 3 RETURNING id, b", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	sql, _, err = b.Dialect(SQLite).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE a SET b = ? WHERE c = ? RETURNING id, b ORDER BY d LIMIT 3", sql)
+
+	sql, _, err = Update("a").Set("b", 1).Where("c = ?", 2).Returning("id", "DELETED.b").Dialect(SQLServer).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE a SET b = ? OUTPUT INSERTED.id, DELETED.b WHERE c = ?", sql)
+
+	_, _, err = b.Dialect(Oracle).ToSQL()
+	assert.Error(t, err)
+
+	_, _, err = b.Dialect(MySQL).ToSQL()
+	assert.Error(t, err)
+}
+
 func TestUpdateBuilderNoRunner(t *testing.T) {
 	b := Update("test").Set("x", 1)
 
 	_, err := b.Exec()
 	assert.Equal(t, ErrRunnerNotSet, err)
 
+	_, err = b.Query()
+	assert.Equal(t, ErrRunnerNotSet, err)
+
+	err = b.Scan()
+	assert.Equal(t, ErrRunnerNotSet, err)
+
 	_, err = b.ExecContext(context.TODO())
 	assert.Equal(t, ErrRunnerNotSet, err)
 }
@@ -133,3 +194,74 @@ func BenchmarkUpdateToSQL(b *testing.B) {
 		qb.ToSQL()
 	}
 }
+
+func TestUpdateBuilderJSON(t *testing.T) {
+	q := func(d Dialect) *UpdateBuilder {
+		return Update("t").
+			SetJSONPath("data", []interface{}{"a", "b c"}, map[string]int{"x": 1}).
+			RemoveJSONPath("data", "old", 0).
+			AppendJSONArray("data", []interface{}{"tags"}, "go").
+			Where("id = ?", 7).
+			Dialect(d)
+	}
+
+	cases := []struct {
+		d        Dialect
+		expected string
+	}{
+		{Postgres, `UPDATE t SET data = jsonb_insert(jsonb_set(data, '{a,"b c"}', ?::jsonb) #- '{old,0}', '{tags,-1}', ?::jsonb, true) WHERE id = ?`},
+		{MySQL, `UPDATE t SET data = JSON_ARRAY_APPEND(JSON_REMOVE(JSON_SET(data, '$.a."b c"', CAST(? AS JSON)), '$.old[0]'), '$.tags', CAST(? AS JSON)) WHERE id = ?`},
+		{SQLite, `UPDATE t SET data = json_insert(json_remove(json_set(data, '$.a."b c"', json(?)), '$.old[0]'), '$.tags[#]', json(?)) WHERE id = ?`},
+		{Oracle, `UPDATE t SET data = JSON_TRANSFORM(JSON_TRANSFORM(JSON_TRANSFORM(data, SET '$.a."b c"' = ? FORMAT JSON), REMOVE '$.old[0]'), APPEND '$.tags' = ? FORMAT JSON) WHERE id = ?`},
+	}
+
+	for _, c := range cases {
+		sql, args, err := q(c.d).ToSQL()
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, sql)
+		assert.Equal(t, []interface{}{`{"x":1}`, `"go"`, 7}, args)
+	}
+
+	sql, args, err := q(SQLServer).PlaceholderFormat(AtP).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE t SET data = JSON_MODIFY(JSON_MODIFY(JSON_MODIFY(data, '$.a."b c"', JSON_QUERY(@p1)), '$.old[0]', NULL), 'append $.tags', @p2) WHERE id = @p3`, sql)
+	assert.Equal(t, []interface{}{`{"x":1}`, "go", 7}, args)
+
+	sql, args, err = Update("t").
+		SetJSONPath("data", []interface{}{"a"}, nil).
+		AppendJSONArray("data", []interface{}{"ids"}, json.RawMessage("[1]")).
+		AppendJSONArray("data", []interface{}{"ids"}, json.RawMessage("2")).
+		Dialect(SQLServer).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE t SET data = JSON_MODIFY(JSON_MODIFY(JSON_MODIFY(data, 'strict $.a', NULL), 'append $.ids', JSON_QUERY(?)), 'append $.ids', ?)`, sql)
+	assert.Equal(t, []interface{}{"[1]", float64(2)}, args)
+
+	sql, args, err = Update("t").
+		Set("name", "x").
+		SetJSONPath("data", []interface{}{"n"}, Expr("other + ?", 1)).
+		RemoveJSONPath("meta", "null").
+		Dialect(Postgres).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE t SET name = ?, data = jsonb_set(data, '{n}', other + ?), meta = meta #- '{"null"}'`, sql)
+	assert.Equal(t, []interface{}{"x", 1}, args)
+
+	sql, _, err = Update("t").RemoveJSONPath("data", "a").Dialect(SQLServer).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE t SET data = JSON_MODIFY(data, '$.a', NULL)", sql)
+
+	_, _, err = Update("t").RemoveJSONPath("data").ToSQL()
+	assert.Error(t, err)
+
+	sql, args, err = Update("t").
+		Set("data", Expr("COALESCE(data, ?::jsonb)", "{}")).
+		SetJSONPath("data", []interface{}{"a"}, 1).
+		Set("meta", map[string]int{"b": 2}).
+		RemoveJSONPath("meta", "c").
+		Dialect(Postgres).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE t SET data = jsonb_set(COALESCE(data, ?::jsonb), '{a}', ?::jsonb), meta = ?::jsonb #- '{c}'", sql)
+	assert.Equal(t, []interface{}{"{}", "1", `{"b":2}`}, args)
+}
@@ -0,0 +1,82 @@
+package sqrl
+
+import (
+	"bytes"
+	"errors"
+)
+
+// ValuesBuilder builds table value constructor "VALUES (...), (...)" which
+// can be used as a FROM source with SelectBuilder.FromValues. MySQL dialect
+// renders it as "VALUES ROW(...), ROW(...)".
+type ValuesBuilder struct {
+	rows [][]interface{}
+}
+
+// Values returns a new ValuesBuilder with a single row of values.
+func Values(values ...interface{}) *ValuesBuilder {
+	return (&ValuesBuilder{}).Values(values...)
+}
+
+// Values adds a single row's values. Values can be Sqlizers.
+func (b *ValuesBuilder) Values(values ...interface{}) *ValuesBuilder {
+	b.rows = append(b.rows, values)
+	return b
+}
+
+// WriteSQL implements Sqlizer
+func (b *ValuesBuilder) WriteSQL(sql *bytes.Buffer) ([]interface{}, error) {
+	return b.writeDialectSQL(sql, dialectOptions{})
+}
+
+func (b *ValuesBuilder) writeDialectSQL(sql *bytes.Buffer, d dialectOptions) ([]interface{}, error) {
+	if len(b.rows) == 0 {
+		return nil, errors.New("values list must have at least one row")
+	}
+
+	// MySQL requires row constructors in a table value constructor, unlike
+	// in VALUES clause of INSERT
+	return appendValuesToSQL(sql, b.rows, d, d.Dialect == MySQL, nil)
+}
+
+// appendValuesToSQL writes "VALUES (?,?),(?,?)", rendering Sqlizer values
+// in place of placeholders. If rowConstructor is true, the rows are written as
+// "ROW(?,?)".
+func appendValuesToSQL(sql *bytes.Buffer, rows [][]interface{}, d dialectOptions, rowConstructor bool, args []interface{}) ([]interface{}, error) {
+	sql.WriteString("VALUES ")
+
+	for r, row := range rows {
+		if r > 0 {
+			sql.WriteString(",")
+		}
+
+		if rowConstructor {
+			sql.WriteString("ROW")
+		}
+		sql.WriteString("(")
+
+		for v, val := range row {
+			if v > 0 {
+				sql.WriteString(",")
+			}
+
+			switch typedVal := val.(type) {
+			case Sqlizer:
+				valArgs, err := writeSQL(sql, typedVal, d)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(valArgs) > 0 {
+					args = append(args, valArgs...)
+				}
+			default:
+				sql.WriteString("?")
+				args = append(args, val)
+			}
+		}
+
+		sql.WriteString(")")
+	}
+
+	return args, nil
+}
@@ -0,0 +1,33 @@
+package sqrl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValuesBuilderToSQL(t *testing.T) {
+	b := &bytes.Buffer{}
+	args, err := Values(1, 2).Values(3, Expr("? + 1", 4)).WriteSQL(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "VALUES (?,?),(?,? + 1)", b.String())
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, args)
+
+	_, err = (&ValuesBuilder{}).WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestValuesBuilderMySQL(t *testing.T) {
+	sql, args, err := Select("v.id").
+		FromValues(Values(1, "a").Values(2, "b"), "v", "id", "code").
+		Dialect(MySQL).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT v.id FROM (VALUES ROW(?,?),ROW(?,?)) AS v(id, code)", sql)
+	assert.Equal(t, []interface{}{1, "a", 2, "b"}, args)
+
+	sql, _, err = Insert("t").Values(1, 2).Dialect(MySQL).ToSQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO t VALUES (?,?)", sql)
+}
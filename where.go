@@ -7,7 +7,7 @@ import (
 
 type wherePart part
 
-func newWherePart(pred interface{}, args ...interface{}) sqlWriter {
+func newWherePart(pred interface{}, args ...interface{}) Sqlizer {
 	if pred == nil {
 		return nil
 	}
@@ -15,15 +15,18 @@ func newWherePart(pred interface{}, args ...interface{}) sqlWriter {
 	return &wherePart{pred: pred, args: args}
 }
 
-func (p wherePart) toSQL(b *bytes.Buffer) (args []interface{}, err error) {
+func (p wherePart) WriteSQL(b *bytes.Buffer) (args []interface{}, err error) {
+	return p.writeDialectSQL(b, dialectOptions{})
+}
+
+func (p wherePart) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
 	switch pred := p.pred.(type) {
-	case sqlWriter:
-		return pred.toSQL(b)
+	case Sqlizer:
+		return writeSQL(b, pred, d)
 	case map[string]interface{}:
-		return Eq(pred).toSQL(b)
+		return writeSQL(b, Eq(pred), d)
 	case string:
-		_, err = b.WriteString(pred)
-		args = p.args
+		return Expr(pred, p.args...).writeDialectSQL(b, d)
 	default:
 		err = fmt.Errorf("expected string-keyed map or string, not %T", pred)
 	}
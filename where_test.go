@@ -10,30 +10,30 @@ import (
 )
 
 func TestWherePartsAppendToSql(t *testing.T) {
-	parts := []sqlWriter{
+	parts := []Sqlizer{
 		newWherePart("x = ?", 1),
 		newWherePart(Eq{"y": 2}),
 	}
 	sql := &bytes.Buffer{}
-	args, _ := appendToSQL(parts, sql, " AND ", []interface{}{})
+	args, _ := appendToSQL(parts, sql, " AND ", dialectOptions{}, []interface{}{})
 	assert.Equal(t, "x = ? AND y = ?", sql.String())
 	assert.Equal(t, []interface{}{1, 2}, args)
 }
 
 func TestWherePartsAppendToSqlErr(t *testing.T) {
-	parts := []sqlWriter{newWherePart(1)}
-	_, err := appendToSQL(parts, &bytes.Buffer{}, "", []interface{}{})
+	parts := []Sqlizer{newWherePart(1)}
+	_, err := appendToSQL(parts, &bytes.Buffer{}, "", dialectOptions{}, []interface{}{})
 	assert.Error(t, err)
 }
 
 func TestWherePartErr(t *testing.T) {
-	_, err := newWherePart(1).toSQL(&bytes.Buffer{})
+	_, err := newWherePart(1).WriteSQL(&bytes.Buffer{})
 	assert.Error(t, err)
 }
 
 func TestWherePartString(t *testing.T) {
 	b := &bytes.Buffer{}
-	args, err := newWherePart("x = ?", 1).toSQL(b)
+	args, err := newWherePart("x = ?", 1).WriteSQL(b)
 	assert.NoError(t, err)
 	assert.Equal(t, "x = ?", b.String())
 	assert.Equal(t, []interface{}{1}, args)
@@ -42,7 +42,7 @@ func TestWherePartString(t *testing.T) {
 func TestWherePartMap(t *testing.T) {
 	test := func(pred interface{}) {
 		b := &bytes.Buffer{}
-		_, err := newWherePart(pred).toSQL(b)
+		_, err := newWherePart(pred).WriteSQL(b)
 		assert.NoError(t, err)
 
 		sql := b.String()
@@ -57,6 +57,6 @@ func TestWherePartMap(t *testing.T) {
 }
 
 func TestWherePartNoArgs(t *testing.T) {
-	_, err := newWherePart(Eq{"test": []string{}}).toSQL(&bytes.Buffer{})
+	_, err := newWherePart(Eq{"test": []string{}}).WriteSQL(&bytes.Buffer{})
 	assert.Equal(t, err, errors.New("equality condition must contain at least one paramater"))
 }
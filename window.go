@@ -0,0 +1,189 @@
+package sqrl
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Window frame bounds for WindowBuilder.Rows, Range and Groups.
+const (
+	UnboundedPreceding = "UNBOUNDED PRECEDING"
+	CurrentRow         = "CURRENT ROW"
+	UnboundedFollowing = "UNBOUNDED FOLLOWING"
+)
+
+// Preceding returns "n PRECEDING" window frame bound.
+func Preceding(n uint64) string {
+	return strconv.FormatUint(n, 10) + " PRECEDING"
+}
+
+// Following returns "n FOLLOWING" window frame bound.
+func Following(n uint64) string {
+	return strconv.FormatUint(n, 10) + " FOLLOWING"
+}
+
+// WindowBuilder builds window specification used by OVER and WINDOW clauses.
+type WindowBuilder struct {
+	base        string
+	partitionBy []string
+	orderBy     []string
+
+	frameUnit  string
+	frameStart string
+	frameEnd   string
+}
+
+// Window returns a new empty WindowBuilder.
+func Window() *WindowBuilder {
+	return &WindowBuilder{}
+}
+
+// Extends sets the name of an existing window the specification is based on,
+// e.g. a window defined with SelectBuilder.Window.
+func (w *WindowBuilder) Extends(name string) *WindowBuilder {
+	w.base = name
+	return w
+}
+
+// PartitionBy adds PARTITION BY expressions to the window.
+func (w *WindowBuilder) PartitionBy(partitionBy ...string) *WindowBuilder {
+	w.partitionBy = append(w.partitionBy, partitionBy...)
+	return w
+}
+
+// OrderBy adds ORDER BY expressions to the window.
+func (w *WindowBuilder) OrderBy(orderBy ...string) *WindowBuilder {
+	w.orderBy = append(w.orderBy, orderBy...)
+	return w
+}
+
+// Rows sets "ROWS BETWEEN start AND end" frame clause of the window.
+// If end is empty the frame is "ROWS start". start must not be empty.
+func (w *WindowBuilder) Rows(start, end string) *WindowBuilder {
+	return w.frame("ROWS", start, end)
+}
+
+// Range sets "RANGE BETWEEN start AND end" frame clause of the window.
+// If end is empty the frame is "RANGE start". start must not be empty.
+func (w *WindowBuilder) Range(start, end string) *WindowBuilder {
+	return w.frame("RANGE", start, end)
+}
+
+// Groups sets "GROUPS BETWEEN start AND end" frame clause of the window.
+// If end is empty the frame is "GROUPS start". start must not be empty.
+func (w *WindowBuilder) Groups(start, end string) *WindowBuilder {
+	return w.frame("GROUPS", start, end)
+}
+
+func (w *WindowBuilder) frame(unit, start, end string) *WindowBuilder {
+	w.frameUnit = unit
+	w.frameStart = start
+	w.frameEnd = end
+	return w
+}
+
+// WriteSQL implements Sqlizer. It writes the window specification enclosed in
+// parentheses.
+func (w *WindowBuilder) WriteSQL(b *bytes.Buffer) ([]interface{}, error) {
+	var clauses []string
+
+	if len(w.base) > 0 {
+		clauses = append(clauses, w.base)
+	}
+
+	if len(w.partitionBy) > 0 {
+		clauses = append(clauses, "PARTITION BY "+strings.Join(w.partitionBy, ", "))
+	}
+
+	if len(w.orderBy) > 0 {
+		clauses = append(clauses, "ORDER BY "+strings.Join(w.orderBy, ", "))
+	}
+
+	if len(w.frameUnit) > 0 {
+		if len(w.frameStart) == 0 {
+			return nil, errors.New("window frame must have a start bound")
+		}
+
+		if len(w.frameEnd) > 0 {
+			clauses = append(clauses, w.frameUnit+" BETWEEN "+w.frameStart+" AND "+w.frameEnd)
+		} else {
+			clauses = append(clauses, w.frameUnit+" "+w.frameStart)
+		}
+	}
+
+	b.WriteByte('(')
+	b.WriteString(strings.Join(clauses, " "))
+	b.WriteByte(')')
+
+	return nil, nil
+}
+
+type overExpr struct {
+	fn     Sqlizer
+	window Sqlizer
+}
+
+// Over builds window function call "fn OVER (window)". fn is either a string
+// or a Sqlizer, e.g. a result of Filter, and window is either a
+// *WindowBuilder or a name of window defined with SelectBuilder.Window.
+//
+// Ex:
+//
+//	.Column(Alias(Over("ROW_NUMBER()", Window().PartitionBy("dept").OrderBy("salary DESC")), "rn"))
+func Over(fn interface{}, window interface{}) Sqlizer {
+	return overExpr{fn: newPart(fn), window: newPart(window)}
+}
+
+func (e overExpr) WriteSQL(b *bytes.Buffer) (args []interface{}, err error) {
+	return e.writeDialectSQL(b, dialectOptions{})
+}
+
+func (e overExpr) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	args, err = writeSQL(b, e.fn, d)
+	if err != nil {
+		return
+	}
+
+	b.WriteString(" OVER ")
+
+	return appendToSQL([]Sqlizer{e.window}, b, "", d, args)
+}
+
+type filterExpr struct {
+	aggregate Sqlizer
+	pred      Sqlizer
+}
+
+// Filter builds aggregate call with a FILTER clause: "aggregate FILTER (WHERE pred)".
+// The pred and args are handled the same way as in SelectBuilder.Where.
+//
+// Ex:
+//
+//	.Column(Alias(Filter("COUNT(*)", Eq{"status": "paid"}), "paid"))
+func Filter(aggregate interface{}, pred interface{}, args ...interface{}) Sqlizer {
+	return filterExpr{aggregate: newPart(aggregate), pred: newWherePart(pred, args...)}
+}
+
+func (e filterExpr) WriteSQL(b *bytes.Buffer) (args []interface{}, err error) {
+	return e.writeDialectSQL(b, dialectOptions{})
+}
+
+func (e filterExpr) writeDialectSQL(b *bytes.Buffer, d dialectOptions) (args []interface{}, err error) {
+	args, err = writeSQL(b, e.aggregate, d)
+	if err != nil {
+		return
+	}
+
+	b.WriteString(" FILTER (WHERE ")
+
+	args, err = appendToSQL([]Sqlizer{e.pred}, b, "", d, args)
+	if err != nil {
+		return
+	}
+
+	b.WriteByte(')')
+
+	return
+}
@@ -0,0 +1,69 @@
+package sqrl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindowBuilderToSQL(t *testing.T) {
+	tests := []struct {
+		w   *WindowBuilder
+		sql string
+	}{
+		{Window(), "()"},
+		{Window().PartitionBy("a", "b").OrderBy("c DESC"), "(PARTITION BY a, b ORDER BY c DESC)"},
+		{Window().Extends("w").OrderBy("c"), "(w ORDER BY c)"},
+		{Window().OrderBy("c").Rows(UnboundedPreceding, CurrentRow), "(ORDER BY c ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)"},
+		{Window().Range(Preceding(3), Following(2)), "(RANGE BETWEEN 3 PRECEDING AND 2 FOLLOWING)"},
+		{Window().Groups(UnboundedPreceding, ""), "(GROUPS UNBOUNDED PRECEDING)"},
+	}
+
+	for _, test := range tests {
+		b := &bytes.Buffer{}
+		args, err := test.w.WriteSQL(b)
+		assert.NoError(t, err)
+		assert.Empty(t, args)
+		assert.Equal(t, test.sql, b.String())
+	}
+
+	_, err := Window().OrderBy("c").Rows("", "").WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+
+	_, err = Window().Range("", CurrentRow).WriteSQL(&bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestOverAndFilter(t *testing.T) {
+	b := &bytes.Buffer{}
+	args, err := Over(Filter("SUM(amount)", "status = ?", "paid"), "w").WriteSQL(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(amount) FILTER (WHERE status = ?) OVER w", b.String())
+	assert.Equal(t, []interface{}{"paid"}, args)
+}
+
+func TestSelectBuilderWindow(t *testing.T) {
+	rn := Over("ROW_NUMBER()", Window().PartitionBy("dept").OrderBy("salary DESC"))
+
+	sql, args, err := Select("name").
+		Column(Alias(rn, "rn")).
+		Column(Alias(Over(Filter("COUNT(*)", Eq{"active": true}), "w"), "active")).
+		From("employees").
+		Where("salary > ?", 100).
+		Window("w", Window().PartitionBy("dept")).
+		OrderByClause(Over("RANK()", Window().Extends("w").OrderBy("age"))).
+		OrderBy("name").
+		PlaceholderFormat(Dollar).
+		ToSQL()
+
+	assert.NoError(t, err)
+	expectedSQL := "SELECT name, " +
+		"(ROW_NUMBER() OVER (PARTITION BY dept ORDER BY salary DESC)) AS rn, " +
+		"(COUNT(*) FILTER (WHERE active = $1) OVER w) AS active " +
+		"FROM employees WHERE salary > $2 " +
+		"WINDOW w AS (PARTITION BY dept) " +
+		"ORDER BY RANK() OVER (w ORDER BY age), name"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []interface{}{true, 100}, args)
+}